@@ -0,0 +1,51 @@
+package swego
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ErrAsteroidNumber is returned by CalcAsteroid and AsteroidName for an
+// asteroid number less than 1.
+var ErrAsteroidNumber = Error{Code: -1, Message: "invalid asteroid number"}
+
+// AsteroidFileError is returned by CalcAsteroid if the ephemeris file of the
+// asteroid is not found in the ephemeris path.
+type AsteroidFileError struct {
+	Number  int    // asteroid number
+	Message string // error message of the library
+}
+
+func (e *AsteroidFileError) Error() string {
+	return "swisseph: asteroid " + strconv.Itoa(e.Number) + ": " + e.Message
+}
+
+// CalcAsteroid calls Calc for the asteroid with minor planet number astNum,
+// e.g. 433 for Eros, which is body number AstOffset + 433. The asteroid
+// requires its own ephemeris file, named se#####s.se1 for numbers below
+// 100000, in the ephemeris path. It returns ErrAsteroidNumber if astNum is
+// less than 1 and an *AsteroidFileError if the ephemeris file is missing.
+func CalcAsteroid(swe Interface, et float64, astNum int, fl *CalcFlags) ([]float64, int, error) {
+	if astNum < 1 {
+		return nil, -1, ErrAsteroidNumber
+	}
+
+	xx, cfl, err := swe.Calc(et, Planet(AstOffset+astNum), fl)
+	if e, ok := err.(Error); ok && cfl < 0 && strings.Contains(e.Message, "not found") {
+		return xx, cfl, &AsteroidFileError{Number: astNum, Message: e.Message}
+	}
+
+	return xx, cfl, err
+}
+
+// AsteroidName calls PlanetName for the asteroid with minor planet number
+// astNum. The name is read from the ephemeris file of the asteroid, if the
+// file is missing the library returns a name of the form "433: not found".
+// It returns ErrAsteroidNumber if astNum is less than 1.
+func AsteroidName(swe Interface, astNum int) (string, error) {
+	if astNum < 1 {
+		return "", ErrAsteroidNumber
+	}
+
+	return swe.PlanetName(Planet(AstOffset + astNum))
+}
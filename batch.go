@@ -0,0 +1,205 @@
+package swego
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// CalcResult is the result of a single Calc or CalcUT call. In JSON the
+// position is encoded as an object with the fields longitude, latitude,
+// distance, speedLong, speedLat and speedDist, which hold the coordinates in
+// the order of XX independent of the coordinate system selected by the flags.
+// A warning is encoded as its message.
+type CalcResult struct {
+	Planet  Planet
+	XX      []float64 // position and speed, see Calc
+	Flags   int       // flags used by the calculation
+	Warning error     // warning reported by the calculation, see IsWarning
+}
+
+type calcPosJSON struct {
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+	Distance  float64 `json:"distance"`
+	SpeedLong float64 `json:"speedLong"`
+	SpeedLat  float64 `json:"speedLat"`
+	SpeedDist float64 `json:"speedDist"`
+}
+
+type calcResultJSON struct {
+	Planet   Planet       `json:"planet"`
+	Position *calcPosJSON `json:"position,omitempty"`
+	Flags    int          `json:"flags"`
+	Warning  string       `json:"warning,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r CalcResult) MarshalJSON() ([]byte, error) {
+	v := calcResultJSON{Planet: r.Planet, Flags: r.Flags}
+	if len(r.XX) >= 6 {
+		v.Position = &calcPosJSON{r.XX[0], r.XX[1], r.XX[2], r.XX[3], r.XX[4], r.XX[5]}
+	}
+
+	var e Error
+	if errors.As(r.Warning, &e) {
+		v.Warning = e.Message
+	} else if r.Warning != nil {
+		v.Warning = r.Warning.Error()
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A warning is decoded as an Error
+// with the flags as return code.
+func (r *CalcResult) UnmarshalJSON(data []byte) error {
+	var v calcResultJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*r = CalcResult{Planet: v.Planet, Flags: v.Flags}
+	if p := v.Position; p != nil {
+		r.XX = []float64{p.Longitude, p.Latitude, p.Distance, p.SpeedLong, p.SpeedLat, p.SpeedDist}
+	}
+
+	if v.Warning != "" {
+		r.Warning = Error{Code: v.Flags, Message: v.Warning, Warning: true}
+	}
+
+	return nil
+}
+
+// CalcAll calls Calc for each body in pls at the same instant et and with the
+// same flags fl. All calls are made while swe is exclusively locked, see
+// Locked. Warnings are stored with the result of the body and do not stop the
+// calculation. The first other error is returned together with the results of
+// the bodies before the failing one.
+func CalcAll(swe Interface, et float64, pls []Planet, fl *CalcFlags) ([]CalcResult, error) {
+	return calcAll(swe, Interface.Calc, et, pls, fl)
+}
+
+// CalcAllUT is equal to CalcAll but calls CalcUT with ut.
+func CalcAllUT(swe Interface, ut float64, pls []Planet, fl *CalcFlags) ([]CalcResult, error) {
+	return calcAll(swe, Interface.CalcUT, ut, pls, fl)
+}
+
+type calcFunc func(swe Interface, jd float64, pl Planet, fl *CalcFlags) ([]float64, int, error)
+
+func calcAll(swe Interface, fn calcFunc, jd float64, pls []Planet, fl *CalcFlags) (res []CalcResult, err error) {
+	res = make([]CalcResult, 0, len(pls))
+
+	Locked(swe, func(swe Interface) {
+		for _, pl := range pls {
+			xx, cfl, cerr := fn(swe, jd, pl, fl)
+			if cerr != nil && !IsWarning(cerr) {
+				err = cerr
+				return
+			}
+
+			res = append(res, CalcResult{pl, xx, cfl, cerr})
+		}
+	})
+
+	return res, err
+}
+
+// CalcSeries calls Calc for body pl at each instant in ets with the same flags
+// fl. All calls are made while swe is exclusively locked, see Locked. The
+// returned results are aligned with ets. An error does not stop the
+// calculation, it is stored at the index of the failing instant in the
+// returned error slice and the result at that index only has Planet set. The
+// error slice is nil if no error occurred. Warnings are stored with the
+// results.
+func CalcSeries(swe Interface, pl Planet, fl *CalcFlags, ets []float64) ([]CalcResult, []error) {
+	return calcSeries(swe, Interface.Calc, pl, fl, ets)
+}
+
+// CalcSeriesUT is equal to CalcSeries but calls CalcUT with each instant in
+// uts.
+func CalcSeriesUT(swe Interface, pl Planet, fl *CalcFlags, uts []float64) ([]CalcResult, []error) {
+	return calcSeries(swe, Interface.CalcUT, pl, fl, uts)
+}
+
+func calcSeries(swe Interface, fn calcFunc, pl Planet, fl *CalcFlags, jds []float64) (res []CalcResult, errs []error) {
+	res = make([]CalcResult, len(jds))
+
+	Locked(swe, func(swe Interface) {
+		for i, jd := range jds {
+			res[i].Planet = pl
+
+			xx, cfl, err := fn(swe, jd, pl, fl)
+			if err != nil && !IsWarning(err) {
+				if errs == nil {
+					errs = make([]error, len(jds))
+				}
+
+				errs[i] = err
+				continue
+			}
+
+			res[i] = CalcResult{pl, xx, cfl, err}
+		}
+	})
+
+	return res, errs
+}
+
+// DeltaTExSeries calls DeltaTEx for each Julian Date in jds with the same
+// ephemeris eph. All calls are made while swe is exclusively locked, see
+// Locked, so the ΔT values are consistent with each other. The first error is
+// returned together with the values of the dates before the failing one. A
+// warning does not stop the calculation, the first warning is returned with
+// all values if no error occurs.
+func DeltaTExSeries(swe Interface, jds []float64, eph Ephemeris) (dts []float64, err error) {
+	dts = make([]float64, 0, len(jds))
+
+	Locked(swe, func(swe Interface) {
+		for _, jd := range jds {
+			dt, dterr := swe.DeltaTEx(jd, eph)
+			if dterr != nil && !IsWarning(dterr) {
+				err = dterr
+				return
+			}
+
+			if err == nil {
+				err = dterr
+			}
+
+			dts = append(dts, dt)
+		}
+	})
+
+	return dts, err
+}
+
+// HousesSeries calls HousesEx for location loc and house system hsys at each
+// instant in uts. All calls are made while swe is exclusively locked, see
+// Locked. The returned results are aligned with uts. An error does not stop
+// the calculation, it is stored at the index of the instant in the returned
+// error slice. This includes warning ErrHouseFallback, for which the result
+// holds the cusps of the Porphyrius house system. The error slice is nil if
+// no error occurred.
+func HousesSeries(swe Interface, uts []float64, loc *GeoLoc, hsys HSys) ([]HousesResult, []error) {
+	res := make([]HousesResult, len(uts))
+	var errs []error
+
+	Locked(swe, func(swe Interface) {
+		for i, ut := range uts {
+			cusps, ascmc, err := swe.HousesEx(ut, nil, loc, hsys)
+			if err != nil {
+				if errs == nil {
+					errs = make([]error, len(uts))
+				}
+
+				errs[i] = err
+			}
+
+			if len(ascmc) >= 8 {
+				res[i] = NewHousesResult(cusps, ascmc)
+			}
+		}
+	})
+
+	return res, errs
+}
@@ -0,0 +1,181 @@
+package swego
+
+import (
+	"container/list"
+	"math"
+	"sync"
+)
+
+// deltaTCacheSize is the number of ΔT values kept by a DeltaTCache.
+const deltaTCacheSize = 4096
+
+// deltaTCacheRes is the resolution of the cache keys in days, about 1 ms.
+// ΔT changes much less than float64 precision within this interval.
+const deltaTCacheRes = 1e-8
+
+// CachedDeltaT returns a library handle that memoizes the results of DeltaTEx
+// in a least recently used cache keyed by the Julian Date rounded to about a
+// millisecond and the ephemeris. All other functions are passed through to
+// swe. Errors are not cached, warnings are cached along with the value. If swe
+// is nil, it panics.
+//
+// ΔT depends on library state that is not part of the Interface, such as the
+// tidal acceleration, a user defined ΔT and the astronomical models. Change
+// this state via the SetTidAcc, SetDeltaTUserDef and SetAstroModels methods
+// of the returned handle, which invalidate the cache, or call Invalidate after
+// changing it directly on the underlying handle.
+func CachedDeltaT(swe Interface) *DeltaTCache {
+	if swe == nil {
+		panic("swe is nil")
+	}
+
+	return &DeltaTCache{
+		Interface: swe,
+		entries:   make(map[deltaTKey]*list.Element),
+		lru:       list.New(),
+	}
+}
+
+// DeltaTCache is a library handle that caches ΔT values, see CachedDeltaT.
+// It is safe for concurrent use if the underlying handle is.
+type DeltaTCache struct {
+	Interface
+
+	mu      sync.Mutex
+	entries map[deltaTKey]*list.Element
+	lru     *list.List // front is most recently used
+	gen     uint64     // incremented by Invalidate
+}
+
+// deltaTKey holds the bits of the rounded Julian Date instead of an integer,
+// which overflows for dates far outside of any ephemeris.
+type deltaTKey struct {
+	jd  uint64
+	eph Ephemeris
+}
+
+type deltaTEntry struct {
+	key  deltaTKey
+	dt   float64
+	warn error // warning returned along with dt
+}
+
+// DeltaTEx returns the ΔT for the Julian Date jd from the cache or calls
+// DeltaTEx of the underlying handle.
+func (c *DeltaTCache) DeltaTEx(jd float64, eph Ephemeris) (float64, error) {
+	return c.deltaTEx(c.Interface, jd, eph)
+}
+
+func (c *DeltaTCache) deltaTEx(swe Interface, jd float64, eph Ephemeris) (float64, error) {
+	key := deltaTKey{math.Float64bits(math.Round(jd / deltaTCacheRes)), eph}
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		entry := e.Value.(*deltaTEntry)
+		c.mu.Unlock()
+		return entry.dt, entry.warn
+	}
+	gen := c.gen
+	c.mu.Unlock()
+
+	dt, err := swe.DeltaTEx(jd, eph)
+	if err != nil && !IsWarning(err) {
+		return dt, err
+	}
+
+	// Don't cache a value that may be computed with the state from before a
+	// concurrent Invalidate.
+	c.mu.Lock()
+	if _, ok := c.entries[key]; !ok && gen == c.gen {
+		c.entries[key] = c.lru.PushFront(&deltaTEntry{key, dt, err})
+		if c.lru.Len() > deltaTCacheSize {
+			e := c.lru.Back()
+			c.lru.Remove(e)
+			delete(c.entries, e.Value.(*deltaTEntry).key)
+		}
+	}
+	c.mu.Unlock()
+
+	return dt, err
+}
+
+// ExclusiveLock exclusively locks the underlying handle if it implements
+// ExclusiveLocker, so that Locked and the helpers using it lock a cached
+// handle. The locked handle shares the cache with c.
+func (c *DeltaTCache) ExclusiveLock() LockedInterface {
+	li := &deltaTCacheLocked{LockedInterface: nopLocked{c.Interface}, c: c}
+	if l, ok := c.Interface.(ExclusiveLocker); ok {
+		li.LockedInterface = l.ExclusiveLock()
+	}
+
+	return li
+}
+
+type deltaTCacheLocked struct {
+	LockedInterface
+	c *DeltaTCache
+}
+
+func (l *deltaTCacheLocked) DeltaTEx(jd float64, eph Ephemeris) (float64, error) {
+	return l.c.deltaTEx(l.LockedInterface, jd, eph)
+}
+
+func (l *deltaTCacheLocked) SetTidAcc(tacc float64) {
+	l.c.setTidAcc(l.LockedInterface, tacc)
+}
+
+func (l *deltaTCacheLocked) SetDeltaTUserDef(dt float64) {
+	l.c.setDeltaTUserDef(l.LockedInterface, dt)
+}
+
+func (l *deltaTCacheLocked) SetAstroModels(samod string, eph Ephemeris) {
+	l.c.setAstroModels(l.LockedInterface, samod, eph)
+}
+
+// Invalidate removes all values from the cache.
+func (c *DeltaTCache) Invalidate() {
+	c.mu.Lock()
+	c.entries = make(map[deltaTKey]*list.Element)
+	c.lru.Init()
+	c.gen++
+	c.mu.Unlock()
+}
+
+// SetTidAcc calls SetTidAcc of the underlying handle, if it has such a method,
+// and invalidates the cache.
+func (c *DeltaTCache) SetTidAcc(tacc float64) { c.setTidAcc(c.Interface, tacc) }
+
+func (c *DeltaTCache) setTidAcc(swe Interface, tacc float64) {
+	if swe, ok := swe.(interface{ SetTidAcc(float64) }); ok {
+		swe.SetTidAcc(tacc)
+	}
+
+	c.Invalidate()
+}
+
+// SetDeltaTUserDef calls SetDeltaTUserDef of the underlying handle, if it has
+// such a method, and invalidates the cache.
+func (c *DeltaTCache) SetDeltaTUserDef(dt float64) { c.setDeltaTUserDef(c.Interface, dt) }
+
+func (c *DeltaTCache) setDeltaTUserDef(swe Interface, dt float64) {
+	if swe, ok := swe.(interface{ SetDeltaTUserDef(float64) }); ok {
+		swe.SetDeltaTUserDef(dt)
+	}
+
+	c.Invalidate()
+}
+
+// SetAstroModels calls SetAstroModels of the underlying handle, if it has such
+// a method, and invalidates the cache.
+func (c *DeltaTCache) SetAstroModels(samod string, eph Ephemeris) {
+	c.setAstroModels(c.Interface, samod, eph)
+}
+
+func (c *DeltaTCache) setAstroModels(swe Interface, samod string, eph Ephemeris) {
+	if swe, ok := swe.(interface{ SetAstroModels(string, Ephemeris) }); ok {
+		swe.SetAstroModels(samod, eph)
+	}
+
+	c.Invalidate()
+}
@@ -0,0 +1,120 @@
+package swego
+
+// ErrNoHeliocentric is returned by CalcHeliocentric for bodies that have no
+// heliocentric position, for which the library returns zeros.
+var ErrNoHeliocentric = Error{Code: -1, Message: "body has no heliocentric position"}
+
+// withFlags returns a copy of fl with flags set, fl is not modified.
+func withFlags(fl *CalcFlags, flags int32) *CalcFlags {
+	if fl == nil {
+		return &CalcFlags{Flags: flags}
+	}
+
+	fl = fl.Copy()
+	fl.Flags |= flags
+	return fl
+}
+
+// CalcHeliocentric calls Calc with FlagHelio set in a copy of fl. It returns
+// ErrNoHeliocentric for the Sun, the lunar nodes and the lunar apogees and
+// perigees, which have no heliocentric position.
+func CalcHeliocentric(swe Interface, et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	switch pl {
+	case Sun, MeanNode, TrueNode, MeanApogee, OscuApogee, InterApogee, InterPerigee, EclNut:
+		return nil, -1, ErrNoHeliocentric
+	}
+
+	return swe.Calc(et, pl, withFlags(fl, FlagHelio))
+}
+
+// ErrMoshierBarycentric is returned by CalcBarycentric if the position is
+// computed with the Moshier ephemeris, which has no barycentric positions.
+var ErrMoshierBarycentric = Error{Code: -1, Message: "barycentric positions are not supported by the Moshier ephemeris"}
+
+// CalcBarycentric calls Calc with FlagBary set in a copy of fl, which returns
+// the position relative to the solar system barycenter. Barycentric positions
+// require the Swiss Ephemeris or the JPL ephemeris, with the Moshier ephemeris
+// the library returns an error. ErrMoshierBarycentric is returned if the Swiss
+// Ephemeris files are missing and the library falls back to Moshier.
+func CalcBarycentric(swe Interface, et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	xx, cfl, err := swe.Calc(et, pl, withFlags(fl, FlagBary))
+	if cfl >= 0 && cfl&FlagEphMoshier != 0 {
+		return nil, -1, ErrMoshierBarycentric
+	}
+
+	return xx, cfl, err
+}
+
+// CalcEquatorial calls Calc with FlagEquatorial set in a copy of fl and
+// returns the right ascension ra, declination dec and distance dist. Like in
+// the library ra is in degrees, not in hours. The speeds are only computed if
+// FlagSpeed is set in fl, otherwise they are 0.
+func CalcEquatorial(swe Interface, et float64, pl Planet, fl *CalcFlags) (ra, dec, dist, raSpeed, decSpeed, distSpeed float64, err error) {
+	xx, _, err := swe.Calc(et, pl, withFlags(fl, FlagEquatorial))
+	if len(xx) < 6 {
+		return 0, 0, 0, 0, 0, 0, err
+	}
+
+	return xx[0], xx[1], xx[2], xx[3], xx[4], xx[5], err
+}
+
+// CalcXYZ calls Calc with FlagXYZ set in a copy of fl and returns the
+// rectangular coordinates x, y and z in AU and the speeds vx, vy and vz in AU
+// per day. The frame is the ecliptic and equinox of date, the ecliptic and
+// equinox of J2000 if FlagJ2000 is set in fl, or the equator if
+// FlagEquatorial is set in fl. The speeds are only computed if FlagSpeed is
+// set in fl, otherwise they are 0.
+func CalcXYZ(swe Interface, et float64, pl Planet, fl *CalcFlags) (x, y, z, vx, vy, vz float64, err error) {
+	xx, _, err := swe.Calc(et, pl, withFlags(fl, FlagXYZ))
+	if len(xx) < 6 {
+		return 0, 0, 0, 0, 0, 0, err
+	}
+
+	return xx[0], xx[1], xx[2], xx[3], xx[4], xx[5], err
+}
+
+// CalcJ2000 calls Calc with FlagJ2000 set in a copy of fl, which returns the
+// position relative to the ecliptic and equinox of J2000 instead of the true
+// equinox of date. Without FlagJ2000 Calc includes precession and nutation.
+func CalcJ2000(swe Interface, et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	return swe.Calc(et, pl, withFlags(fl, FlagJ2000))
+}
+
+// CalcGeometric calls Calc with FlagTruePos, FlagNoAbber and FlagNoGDefl set
+// in a copy of fl, which returns the geometric position instead of the
+// apparent position. The flags disable, in that order, the correction for
+// light-time, the annual aberration of light and the gravitational deflection
+// of light by the Sun. Precession and nutation are still applied, set
+// FlagJ2000 or FlagNoNut in fl to disable them.
+func CalcGeometric(swe Interface, et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	return swe.Calc(et, pl, withFlags(fl, FlagTruePos|FlagNoAbber|FlagNoGDefl))
+}
+
+// CalcAstrometric calls Calc with FlagJ2000, FlagNoAbber, FlagNoGDefl and
+// FlagEquatorial set in a copy of fl and returns the astrometric right
+// ascension ra2000 and declination dec2000 in degrees and the distance dist.
+// Astrometric positions are corrected for light-time only and are comparable
+// to the J2000 coordinates of star catalogs like the FK5. Set FlagICRS in fl
+// to compare with ICRF catalogs like Hipparcos, which omits the frame bias
+// between the FK5 and the ICRS.
+func CalcAstrometric(swe Interface, et float64, pl Planet, fl *CalcFlags) (ra2000, dec2000, dist float64, err error) {
+	xx, _, err := swe.Calc(et, pl, withFlags(fl, FlagJ2000|FlagNoAbber|FlagNoGDefl|FlagEquatorial))
+	if len(xx) < 3 {
+		return 0, 0, 0, err
+	}
+
+	return xx[0], xx[1], xx[2], err
+}
+
+// Speed calls Calc with FlagSpeed set in a copy of fl and returns only the
+// daily motion: the speed in longitude lngSpeed and latitude latSpeed in
+// degrees per day and the speed in distance distSpeed in AU per day. Without
+// FlagSpeed the library returns zero speeds, Speed ensures it is set.
+func Speed(swe Interface, et float64, pl Planet, fl *CalcFlags) (lngSpeed, latSpeed, distSpeed float64, err error) {
+	xx, _, err := swe.Calc(et, pl, withFlags(fl, FlagSpeed))
+	if len(xx) < 6 {
+		return 0, 0, 0, err
+	}
+
+	return xx[3], xx[4], xx[5], err
+}
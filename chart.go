@@ -0,0 +1,78 @@
+package swego
+
+// Chart bundles the positions of bodies, the houses and the ayanamsa for one
+// instant and location as computed by ComputeChart. The Ascendant, MC and
+// other angles are part of Houses.
+type Chart struct {
+	UT        float64               `json:"ut"`
+	Positions map[Planet]CalcResult `json:"positions"`
+	Houses    HousesResult          `json:"houses"`
+
+	// Ayanamsa is the ayanamsa of the sidereal mode of the calculation flags
+	// in degrees, or 0 if FlagSidereal is not set.
+	Ayanamsa float64 `json:"ayanamsa,omitempty"`
+}
+
+// ComputeChart returns the chart at ut for location loc. It calls CalcUT for
+// each body in bodies with calculation flags fl, HousesEx with house system
+// hsys and, if FlagSidereal is set in fl, GetAyanamsaExUT. The houses and the
+// ayanamsa use the sidereal mode and ΔT of fl. All calls are made while swe is
+// exclusively locked, see Locked. Warnings of CalcUT are stored with the
+// positions. A house system warning such as ErrHouseFallback is returned
+// together with the complete chart. The first other error is returned
+// together with the part of the chart computed before the failing call.
+func ComputeChart(swe Interface, ut float64, loc *GeoLoc, hsys HSys, bodies []Planet, fl *CalcFlags) (*Chart, error) {
+	if fl == nil {
+		fl = new(CalcFlags)
+	}
+
+	c := &Chart{UT: ut, Positions: make(map[Planet]CalcResult, len(bodies))}
+	var err error
+
+	Locked(swe, func(swe Interface) {
+		var res []CalcResult
+		if res, err = CalcAllUT(swe, ut, bodies, fl); err != nil {
+			return
+		}
+
+		for _, r := range res {
+			c.Positions[r.Planet] = r
+		}
+
+		sidereal := fl.Flags&FlagSidereal != 0
+		hfl := &HousesExFlags{DeltaT: fl.DeltaT}
+		if sidereal {
+			hfl.Flags = FlagSidereal
+			hfl.SidMode = fl.SidMode
+		}
+
+		cusps, ascmc, herr := swe.HousesEx(ut, hfl, loc, hsys)
+		if herr != nil && !IsWarning(herr) {
+			err = herr
+			return
+		}
+
+		if len(ascmc) < 8 {
+			err = ErrShortResult
+			return
+		}
+
+		c.Houses = NewHousesResult(cusps, ascmc)
+		err = herr
+
+		if sidereal {
+			afl := &AyanamsaExFlags{
+				Flags:   fl.Flags &^ (FlagSidereal | FlagSpeed),
+				SidMode: fl.SidMode,
+				DeltaT:  fl.DeltaT,
+			}
+
+			var aerr error
+			if c.Ayanamsa, aerr = swe.GetAyanamsaExUT(ut, afl); aerr != nil {
+				err = aerr
+			}
+		}
+	})
+
+	return c, err
+}
@@ -58,7 +58,9 @@ const (
 
 	EclNut Planet = -1
 
-	AstOffset = 10000
+	AstOffset  = 10000
+	FictOffset = 40  // first fictitious body, see Cupido
+	FictMax    = 999 // last fictitious body number read from seorbel.txt
 )
 
 //go:generate stringer -type=Planet
@@ -101,6 +103,7 @@ const (
 	FlagRadians      = 1 << 13
 	FlagBary         = 1 << 14
 	FlagTopo         = 1 << 15
+	FlagOrbelAA      = FlagTopo // used by swe_get_orbital_elements
 	FlagSidereal     = 1 << 16
 	FlagICRS         = 1 << 17
 	FlagJPLHor       = 1 << 18
@@ -141,10 +144,10 @@ const (
 	SidmTrueRevati         Ayanamsa = 28
 	SidmTruePushya         Ayanamsa = 29
 	SidmGalCentGilBrand    Ayanamsa = 30
-	SidmGalAlignMardyks    Ayanamsa = 31
-	SidmGalEquIAU1958      Ayanamsa = 32
-	SidmGalEquTrue         Ayanamsa = 33
-	SidmGalEquMula         Ayanamsa = 34
+	SidmGalEquIAU1958      Ayanamsa = 31
+	SidmGalEquTrue         Ayanamsa = 32
+	SidmGalEquMula         Ayanamsa = 33
+	SidmGalAlignMardyks    Ayanamsa = 34
 	SidmGalTrueMula        Ayanamsa = 35
 	SidmGalCentMulaWilhelm Ayanamsa = 36
 	SidmAryabhata522       Ayanamsa = 37
@@ -167,6 +170,143 @@ const (
 	NodbitFoPoint  NodApsMethod = 256
 )
 
+// Eclipse types and visibility flags defined in swephexp.h.
+const (
+	EclCentral          EclType = 1
+	EclNonCentral       EclType = 2
+	EclTotal            EclType = 4
+	EclAnnular          EclType = 8
+	EclPartial          EclType = 16
+	EclAnnularTotal     EclType = 32
+	EclPenumbral        EclType = 64
+	EclAllTypesSolar            = EclCentral | EclNonCentral | EclTotal | EclAnnular | EclPartial | EclAnnularTotal
+	EclAllTypesLunar            = EclTotal | EclPartial | EclPenumbral
+	EclVisible          EclType = 128
+	EclMaxVisible       EclType = 256
+	Ecl1stVisible       EclType = 512 // begin of partial eclipse
+	EclPartBegVisible   EclType = 512
+	Ecl2ndVisible       EclType = 1024 // begin of total eclipse
+	EclTotBegVisible    EclType = 1024
+	Ecl3rdVisible       EclType = 2048 // end of total eclipse
+	EclTotEndVisible    EclType = 2048
+	Ecl4thVisible       EclType = 4096 // end of partial eclipse
+	EclPartEndVisible   EclType = 4096
+	EclPenumbBegVisible EclType = 8192  // begin of penumbral eclipse
+	EclPenumbEndVisible EclType = 16384 // end of penumbral eclipse
+	EclOccBegDaylight   EclType = 8192  // occultation begins during the day
+	EclOccEndDaylight   EclType = 16384 // occultation ends during the day
+	EclOneTry           EclType = 32768 // only check the next conjunction
+)
+
+// Conversion modes of swe_azalt defined in swephexp.h.
+const (
+	Ecl2Hor AzaltMode = 0 // ecliptic to horizontal coordinates
+	Equ2Hor AzaltMode = 1 // equatorial to horizontal coordinates
+)
+
+// Conversion modes of swe_azalt_rev defined in swephexp.h.
+const (
+	Hor2Ecl AzaltRevMode = 0 // horizontal to ecliptic coordinates
+	Hor2Equ AzaltRevMode = 1 // horizontal to equatorial coordinates
+)
+
+// Refraction modes defined in swephexp.h.
+const (
+	TrueToApp RefracMode = 0 // true to apparent altitude
+	AppToTrue RefracMode = 1 // apparent to true altitude
+)
+
+// Rise and transit events and modifiers defined in swephexp.h.
+const (
+	CalcRise          RiseTransMode = 1
+	CalcSet           RiseTransMode = 2
+	CalcMTransit      RiseTransMode = 4 // upper meridian transit
+	CalcITransit      RiseTransMode = 8 // lower meridian transit
+	BitDiscCenter     RiseTransMode = 256
+	BitNoRefraction   RiseTransMode = 512
+	BitCivilTwilight  RiseTransMode = 1024
+	BitNauticTwilight RiseTransMode = 2048
+	BitAstroTwilight  RiseTransMode = 4096
+	BitDiscBottom     RiseTransMode = 8192
+	BitFixedDiscSize  RiseTransMode = 16384 // neglect distance effect on disc size
+)
+
+// Split degrees flags defined in swephexp.h.
+const (
+	SplitDegRoundSec SplitDegFlags = 1
+	SplitDegRoundMin SplitDegFlags = 2
+	SplitDegRoundDeg SplitDegFlags = 4
+	SplitDegZodiacal SplitDegFlags = 8
+	SplitDegKeepSign SplitDegFlags = 16 // don't round to next sign
+	SplitDegKeepDeg  SplitDegFlags = 32 // don't round to next degree
+)
+
+// Heliacal events defined in swephexp.h.
+const (
+	HeliacalRising    HeliacalEvent = 1
+	HeliacalSetting   HeliacalEvent = 2
+	MorningFirst      HeliacalEvent = HeliacalRising
+	EveningLast       HeliacalEvent = HeliacalSetting
+	EveningFirst      HeliacalEvent = 3
+	MorningLast       HeliacalEvent = 4
+	AcronychalRising  HeliacalEvent = 5 // not implemented in the C library
+	AcronychalSetting HeliacalEvent = 6 // not implemented in the C library
+	CosmicalSetting   HeliacalEvent = AcronychalSetting
+)
+
+// Heliacal flags defined in swephexp.h.
+const (
+	HelflagLongSearch    = 128
+	HelflagHighPrecision = 256
+	HelflagOpticalParams = 512
+	HelflagNoDetails     = 1024
+	HelflagSearch1Period = 2048
+	HelflagVisLimDark    = 4096
+	HelflagVisLimNoMoon  = 8192
+)
+
+// Indexes of heliacal phenomena returned by swe_heliacal_pheno_ut.
+const (
+	HelTopoAltObj  = 0  // topocentric altitude of object (unrefracted)
+	HelAppAltObj   = 1  // apparent altitude of object (refracted)
+	HelGeoAltObj   = 2  // geocentric altitude of object
+	HelAziObj      = 3  // azimuth of object
+	HelTopoAltSun  = 4  // topocentric altitude of sun
+	HelAziSun      = 5  // azimuth of sun
+	HelTAV         = 6  // actual topocentric arcus visionis
+	HelARCV        = 7  // actual geocentric arcus visionis
+	HelDAZ         = 8  // actual difference between object and sun azimuth
+	HelARCL        = 9  // actual longitude difference between object and sun
+	HelK           = 10 // extinction coefficient
+	HelMinTAV      = 11 // smallest topocentric arcus visionis
+	HelTFirst      = 12 // first time visible
+	HelTBest       = 13 // best time visible
+	HelTLast       = 14 // last time visible
+	HelTBestYallop = 15 // best time visible according to Yallop
+	HelWMoon       = 16 // crescent width of moon
+	HelQYallop     = 17 // q-test value of Yallop
+	HelQCrit       = 18 // q-test criterion of Yallop
+	HelParallax    = 19 // parallax of object
+	HelMagnitude   = 20 // magnitude of object
+	HelRiseSetObj  = 21 // rise or set time of object
+	HelRiseSetSun  = 22 // rise or set time of sun
+	HelLag         = 23 // rise or set time of object minus that of sun
+	HelTVisible    = 24 // visibility duration
+	HelLMoon       = 25 // crescent length of moon
+	HelElong       = 26 // elongation
+	HelIllum       = 27 // illumination in percent
+)
+
+// Gauquelin sector computation methods of swe_gauquelin_sector.
+const (
+	GauquelinWithLat          GauquelinMethod = 0 // Placidus house position
+	GauquelinNoLat            GauquelinMethod = 1 // Placidus house position with latitude 0
+	GauquelinDiscCenter       GauquelinMethod = 2 // rise and set of disc center
+	GauquelinDiscCenterRefrac GauquelinMethod = 3 // rise and set of disc center with refraction
+	GauquelinDiscEdge         GauquelinMethod = 4 // rise and set of disc edge
+	GauquelinDiscEdgeRefrac   GauquelinMethod = 5 // rise and set of disc edge with refraction
+)
+
 // File name of JPL data files defined in swephexp.h.
 const (
 	FnameDE200 = "de200.eph"
@@ -176,6 +316,56 @@ const (
 	FnameDft2  = FnameDE406
 )
 
+// Indexes of the astronomical model slots defined in swephexp.h.
+const (
+	ModelDeltaT           = 0
+	ModelPrecLongterm     = 1
+	ModelPrecShortterm    = 2
+	ModelNut              = 3
+	ModelBias             = 4
+	ModelJPLHorMode       = 5
+	ModelJPLHorApproxMode = 6
+	ModelSidT             = 7
+	NumModels             = 8
+)
+
+// Sidereal time models for slot ModelSidT defined in swephexp.h.
+const (
+	SidTIAU1976      = 1 // IAU 1976 resolution, formula by Aoki et al. (1982)
+	SidTIAU2006      = 2 // IAU 2006 resolution, formula by Capitaine et al. (2003)
+	SidTIERSConv2010 = 3 // IERS Conventions 2010, based on the Earth rotation angle
+	SidTLongterm     = 4 // IERS Conventions 2010 with a long-term extension
+	SidTDefault      = SidTLongterm
+)
+
+// DeltaTAutomatic is the special ΔT value defined in swephexp.h that makes the
+// Swiss Ephemeris use its internal ΔT model.
+const DeltaTAutomatic = -1e-10
+
+// LapseRate is the default atmospheric lapse rate in K/m defined in sweph.h.
+const LapseRate = 0.0065
+
+// Tidal acceleration values of the Moon in arcsec/cy² defined in
+// swephexp.h.
+const (
+	TidalDE200          = -23.8946
+	TidalDE403          = -25.580
+	TidalDE404          = -25.580
+	TidalDE405          = -25.826
+	TidalDE406          = -25.826
+	TidalDE421          = -25.85
+	TidalDE422          = -25.85
+	TidalDE430          = -25.82
+	TidalDE431          = -25.80
+	Tidal26             = -26.0
+	TidalStephenson2016 = -25.85
+	TidalDefault        = TidalDE431
+	TidalAutomatic      = 999999 // reverts to the ephemeris dependent value
+	TidalMoshier        = TidalDE404
+	TidalSwissEph       = TidalDefault
+	TidalJPLEph         = TidalDefault
+)
+
 // House systems implemented in the C library.
 const (
 	Alcabitius           HSys = 'B'
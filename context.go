@@ -0,0 +1,211 @@
+package swego
+
+import "context"
+
+// WithContext returns a library handle that checks ctx before each of the
+// potentially long running search functions (rise and transit, heliacal
+// events, eclipses, occultations and crossings) and before each Calc and
+// CalcUT, and returns ctx.Err() without calling into swe once ctx is done.
+// Checking Calc and CalcUT makes the Go-side searches like NextAspect,
+// NextStation and VoidOfCourseMoon stop at their next step. All other
+// functions are passed through to swe unchecked.
+//
+// The underlying C functions can not be interrupted, so cancellation takes
+// effect between calls: a search that has already started runs to completion.
+// This is sufficient to bound the time spent in a request that performs a
+// sequence of searches. If either argument is nil, it panics.
+func WithContext(ctx context.Context, swe Interface) Interface {
+	if ctx == nil {
+		panic("ctx is nil")
+	}
+
+	if swe == nil {
+		panic("swe is nil")
+	}
+
+	return &ctxInterface{swe, ctx}
+}
+
+type ctxInterface struct {
+	Interface
+	ctx context.Context
+}
+
+// ExclusiveLock exclusively locks the underlying handle if it implements
+// ExclusiveLocker, so that Locked and the helpers using it lock a handle
+// returned by WithContext. The locked handle checks the same ctx.
+func (c *ctxInterface) ExclusiveLock() LockedInterface {
+	var li LockedInterface = nopLocked{c.Interface}
+	if l, ok := c.Interface.(ExclusiveLocker); ok {
+		li = l.ExclusiveLock()
+	}
+
+	return ctxLocked{&ctxInterface{li, c.ctx}, li}
+}
+
+type ctxLocked struct {
+	*ctxInterface
+	inner LockedInterface
+}
+
+func (l ctxLocked) ExclusiveUnlock() { l.inner.ExclusiveUnlock() }
+
+func (c *ctxInterface) Calc(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, -1, err
+	}
+
+	return c.Interface.Calc(et, pl, fl)
+}
+
+func (c *ctxInterface) CalcUT(ut float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, -1, err
+	}
+
+	return c.Interface.CalcUT(ut, pl, fl)
+}
+
+func (c *ctxInterface) SolCross(x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return c.Interface.SolCross(x2cross, et, fl, backward)
+}
+
+func (c *ctxInterface) SolCrossUT(x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return c.Interface.SolCrossUT(x2cross, ut, fl, backward)
+}
+
+func (c *ctxInterface) MoonCross(x2cross, et float64, fl *CalcFlags) (float64, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return c.Interface.MoonCross(x2cross, et, fl)
+}
+
+func (c *ctxInterface) MoonCrossUT(x2cross, ut float64, fl *CalcFlags) (float64, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return c.Interface.MoonCrossUT(x2cross, ut, fl)
+}
+
+func (c *ctxInterface) MoonCrossNode(et float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, 0, 0, err
+	}
+
+	return c.Interface.MoonCrossNode(et, fl)
+}
+
+func (c *ctxInterface) MoonCrossNodeUT(ut float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, 0, 0, err
+	}
+
+	return c.Interface.MoonCrossNodeUT(ut, fl)
+}
+
+func (c *ctxInterface) HelioCross(pl Planet, x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return c.Interface.HelioCross(pl, x2cross, et, fl, backward)
+}
+
+func (c *ctxInterface) HelioCrossUT(pl Planet, x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return c.Interface.HelioCrossUT(pl, x2cross, ut, fl, backward)
+}
+
+func (c *ctxInterface) RiseTrans(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp float64) (float64, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return c.Interface.RiseTrans(ut, pl, star, fl, m, geo, press, temp)
+}
+
+func (c *ctxInterface) RiseTransTrueHor(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp, horhgt float64) (float64, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return c.Interface.RiseTransTrueHor(ut, pl, star, fl, m, geo, press, temp, horhgt)
+}
+
+func (c *ctxInterface) HeliacalUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.Interface.HeliacalUT(ut, geo, atm, obs, obj, ev, fl)
+}
+
+func (c *ctxInterface) HeliacalPhenoUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.Interface.HeliacalPhenoUT(ut, geo, atm, obs, obj, ev, fl)
+}
+
+func (c *ctxInterface) SolEclipseWhenGlob(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return c.Interface.SolEclipseWhenGlob(ut, fl, typ, backward)
+}
+
+func (c *ctxInterface) SolEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, nil, 0, err
+	}
+
+	return c.Interface.SolEclipseWhenLoc(ut, fl, geo, backward)
+}
+
+func (c *ctxInterface) LunEclipseWhen(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return c.Interface.LunEclipseWhen(ut, fl, typ, backward)
+}
+
+func (c *ctxInterface) LunEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, nil, 0, err
+	}
+
+	return c.Interface.LunEclipseWhenLoc(ut, fl, geo, backward)
+}
+
+func (c *ctxInterface) LunOccultWhenGlob(ut float64, pl Planet, star string, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return c.Interface.LunOccultWhenGlob(ut, pl, star, fl, typ, backward)
+}
+
+func (c *ctxInterface) LunOccultWhenLoc(ut float64, pl Planet, star string, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, nil, 0, err
+	}
+
+	return c.Interface.LunOccultWhenLoc(ut, pl, star, fl, geo, backward)
+}
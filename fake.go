@@ -0,0 +1,735 @@
+package swego
+
+import "sync"
+
+// Fake is a programmable library handle intended for tests. Each function
+// calls the hook of the same name with suffix Func, for example CalcFunc for
+// Calc, if it is set. Otherwise it returns zero values and a nil error. Calc
+// and CalcUT first return the responses registered by SetCalc and SetCalcUT
+// and return a zero position with six elements if no response or hook is
+// found. The hooks must not be changed while the Fake is in use by other
+// goroutines.
+//
+// Example:
+//
+//	fake := new(swego.Fake)
+//	fake.SetCalc(2451545.0, swego.Sun, []float64{280.4, 0, 1, 1, 0, 0}, 0, nil)
+//	fake.HouseNameFunc = func(hsys swego.HSys) (string, error) {
+//		return "Placidus", nil
+//	}
+type Fake struct {
+	FakeFuncs
+
+	mu     sync.Mutex
+	calc   map[fakeCalcKey]fakeCalcResp
+	calcUT map[fakeCalcKey]fakeCalcResp
+}
+
+var _ Interface = (*Fake)(nil) // assert interface
+
+type fakeCalcKey struct {
+	jd float64
+	pl Planet
+}
+
+type fakeCalcResp struct {
+	xx  []float64
+	cfl int
+	err error
+}
+
+// SetCalc registers the response of Calc for body pl at et, independent of the
+// flags passed.
+func (f *Fake) SetCalc(et float64, pl Planet, xx []float64, cfl int, err error) {
+	f.mu.Lock()
+	if f.calc == nil {
+		f.calc = make(map[fakeCalcKey]fakeCalcResp)
+	}
+
+	f.calc[fakeCalcKey{et, pl}] = fakeCalcResp{xx, cfl, err}
+	f.mu.Unlock()
+}
+
+// SetCalcUT registers the response of CalcUT for body pl at ut, independent
+// of the flags passed.
+func (f *Fake) SetCalcUT(ut float64, pl Planet, xx []float64, cfl int, err error) {
+	f.mu.Lock()
+	if f.calcUT == nil {
+		f.calcUT = make(map[fakeCalcKey]fakeCalcResp)
+	}
+
+	f.calcUT[fakeCalcKey{ut, pl}] = fakeCalcResp{xx, cfl, err}
+	f.mu.Unlock()
+}
+
+// lookupCalc returns the response registered by SetCalcUT if ut is true or
+// by SetCalc otherwise.
+func (f *Fake) lookupCalc(ut bool, jd float64, pl Planet) (fakeCalcResp, bool) {
+	f.mu.Lock()
+	tab := f.calc
+	if ut {
+		tab = f.calcUT
+	}
+
+	resp, ok := tab[fakeCalcKey{jd, pl}]
+	f.mu.Unlock()
+
+	if ok && resp.xx != nil {
+		resp.xx = append([]float64(nil), resp.xx...)
+	}
+
+	return resp, ok
+}
+
+// Calc returns the response registered by SetCalc or calls CalcFunc.
+func (f *Fake) Calc(et float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	if resp, ok := f.lookupCalc(false, et, pl); ok {
+		return resp.xx, resp.cfl, resp.err
+	}
+
+	if f.CalcFunc != nil {
+		return f.CalcFunc(et, pl, fl)
+	}
+
+	return make([]float64, 6), 0, nil
+}
+
+// CalcUT returns the response registered by SetCalcUT or calls CalcUTFunc.
+func (f *Fake) CalcUT(ut float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	if resp, ok := f.lookupCalc(true, ut, pl); ok {
+		return resp.xx, resp.cfl, resp.err
+	}
+
+	if f.CalcUTFunc != nil {
+		return f.CalcUTFunc(ut, pl, fl)
+	}
+
+	return make([]float64, 6), 0, nil
+}
+
+// FakeFuncs contains the hooks of Fake, one per function of Interface.
+type FakeFuncs struct {
+	VersionFunc                 func() (string, error)
+	PlanetNameFunc              func(pl Planet) (string, error)
+	CalcFunc                    func(et float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error)
+	CalcUTFunc                  func(ut float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error)
+	CalcPctrFunc                func(et float64, pl, plctr Planet, fl *CalcFlags) (xx []float64, cfl int, err error)
+	FixStarFunc                 func(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error)
+	FixStarUTFunc               func(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error)
+	FixStar2Func                func(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error)
+	FixStar2UTFunc              func(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error)
+	FixStarMagFunc              func(star string) (name string, mag float64, err error)
+	NodApsFunc                  func(et float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error)
+	NodApsUTFunc                func(ut float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error)
+	PhenoFunc                   func(et float64, pl Planet, fl *CalcFlags) (attr []float64, err error)
+	PhenoUTFunc                 func(ut float64, pl Planet, fl *CalcFlags) (attr []float64, err error)
+	OrbitalElementsFunc         func(et float64, pl Planet, fl *CalcFlags) ([]float64, error)
+	OrbitMaxMinTrueDistanceFunc func(et float64, pl Planet, fl *CalcFlags) (dmax, dmin, dtrue float64, err error)
+	SolCrossFunc                func(x2cross, et float64, fl *CalcFlags, backward bool) (float64, error)
+	SolCrossUTFunc              func(x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error)
+	MoonCrossFunc               func(x2cross, et float64, fl *CalcFlags) (float64, error)
+	MoonCrossUTFunc             func(x2cross, ut float64, fl *CalcFlags) (float64, error)
+	MoonCrossNodeFunc           func(et float64, fl *CalcFlags) (jd, xlon, xlat float64, err error)
+	MoonCrossNodeUTFunc         func(ut float64, fl *CalcFlags) (jd, xlon, xlat float64, err error)
+	HelioCrossFunc              func(pl Planet, x2cross, et float64, fl *CalcFlags, backward bool) (float64, error)
+	HelioCrossUTFunc            func(pl Planet, x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error)
+	GetAyanamsaExFunc           func(et float64, fl *AyanamsaExFlags) (float64, error)
+	GetAyanamsaExUTFunc         func(ut float64, fl *AyanamsaExFlags) (float64, error)
+	GetAyanamsaNameFunc         func(ayan Ayanamsa) (string, error)
+	JulDayFunc                  func(y, m, d int, h float64, ct CalType) (float64, error)
+	DateConversionFunc          func(y, m, d int, h float64, ct CalType) (float64, error)
+	RevJulFunc                  func(jd float64, ct CalType) (y, m, d int, h float64, err error)
+	UTCToJDFunc                 func(y, m, d, h, i int, s float64, fl *DateConvertFlags) (et, ut float64, err error)
+	JdETToUTCFunc               func(et float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error)
+	JdUT1ToUTCFunc              func(ut1 float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error)
+	DayOfWeekFunc               func(jd float64) (int, error)
+	HousesExFunc                func(ut float64, fl *HousesExFlags, geo *GeoLoc, hsys HSys) ([]float64, []float64, error)
+	HousesARMCFunc              func(armc, geolat, eps float64, hsys HSys) ([]float64, []float64, error)
+	HousePosFunc                func(armc, geolat, eps float64, hsys HSys, pllng, pllat float64) (float64, error)
+	HouseNameFunc               func(hsys HSys) (string, error)
+	GauquelinSectorFunc         func(ut float64, pl Planet, star string, fl *CalcFlags, m GauquelinMethod, geo *GeoLoc, press, temp float64) (float64, error)
+	DeltaTExFunc                func(jd float64, eph Ephemeris) (float64, error)
+	TimeEquFunc                 func(jd float64, fl *TimeEquFlags) (float64, error)
+	LMTToLATFunc                func(jdLMT, geolon float64, fl *TimeEquFlags) (float64, error)
+	LATToLMTFunc                func(jdLAT, geolon float64, fl *TimeEquFlags) (float64, error)
+	SidTime0Func                func(ut, eps, nut float64, fl *SidTimeFlags) (float64, error)
+	SidTimeFunc                 func(ut float64, fl *SidTimeFlags) (float64, error)
+	AzaltFunc                   func(ut float64, m AzaltMode, geo *GeoLoc, press, temp float64, in [3]float64, fl *AzaltFlags) ([]float64, error)
+	AzaltRevFunc                func(ut float64, m AzaltRevMode, geo *GeoLoc, in [2]float64, fl *AzaltFlags) ([]float64, error)
+	RefracFunc                  func(alt, press, temp float64, m RefracMode) (float64, error)
+	RefracExtendedFunc          func(alt, geoalt, press, temp, lapseRate float64, m RefracMode) (float64, []float64, error)
+	RiseTransFunc               func(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp float64) (float64, error)
+	RiseTransTrueHorFunc        func(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp, horhgt float64) (float64, error)
+	HeliacalUTFunc              func(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error)
+	HeliacalPhenoUTFunc         func(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error)
+	VisLimitMagFunc             func(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, fl *HeliacalFlags) (dret []float64, visible bool, err error)
+	SolEclipseWhereFunc         func(ut float64, fl *CalcFlags) (geo, attr []float64, typ EclType, err error)
+	SolEclipseHowFunc           func(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error)
+	SolEclipseWhenGlobFunc      func(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error)
+	SolEclipseWhenLocFunc       func(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error)
+	LunEclipseHowFunc           func(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error)
+	LunEclipseWhenFunc          func(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error)
+	LunEclipseWhenLocFunc       func(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error)
+	LunOccultWhereFunc          func(ut float64, pl Planet, star string, fl *CalcFlags) (geo, attr []float64, typ EclType, err error)
+	LunOccultWhenGlobFunc       func(ut float64, pl Planet, star string, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error)
+	LunOccultWhenLocFunc        func(ut float64, pl Planet, star string, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error)
+	CotransFunc                 func(in [3]float64, eps float64) ([]float64, error)
+	CotransSpFunc               func(in [6]float64, eps float64) ([]float64, error)
+	DegNormFunc                 func(x float64) (float64, error)
+	RadNormFunc                 func(x float64) (float64, error)
+	DegMidpFunc                 func(x1, x0 float64) (float64, error)
+	RadMidpFunc                 func(x1, x0 float64) (float64, error)
+	SplitDegFunc                func(x float64, fl SplitDegFlags) (deg, min, sec int, secfr float64, sign int, err error)
+}
+
+func (f *Fake) Version() (string, error) {
+	if f.VersionFunc != nil {
+		return f.VersionFunc()
+	}
+
+	return "", nil
+}
+
+func (f *Fake) PlanetName(pl Planet) (string, error) {
+	if f.PlanetNameFunc != nil {
+		return f.PlanetNameFunc(pl)
+	}
+
+	return "", nil
+}
+
+func (f *Fake) CalcPctr(et float64, pl, plctr Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	if f.CalcPctrFunc != nil {
+		return f.CalcPctrFunc(et, pl, plctr, fl)
+	}
+
+	return nil, 0, nil
+}
+
+func (f *Fake) FixStar(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	if f.FixStarFunc != nil {
+		return f.FixStarFunc(star, et, fl)
+	}
+
+	return "", nil, 0, nil
+}
+
+func (f *Fake) FixStarUT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	if f.FixStarUTFunc != nil {
+		return f.FixStarUTFunc(star, ut, fl)
+	}
+
+	return "", nil, 0, nil
+}
+
+func (f *Fake) FixStar2(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	if f.FixStar2Func != nil {
+		return f.FixStar2Func(star, et, fl)
+	}
+
+	return "", nil, 0, nil
+}
+
+func (f *Fake) FixStar2UT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	if f.FixStar2UTFunc != nil {
+		return f.FixStar2UTFunc(star, ut, fl)
+	}
+
+	return "", nil, 0, nil
+}
+
+func (f *Fake) FixStarMag(star string) (name string, mag float64, err error) {
+	if f.FixStarMagFunc != nil {
+		return f.FixStarMagFunc(star)
+	}
+
+	return "", 0, nil
+}
+
+func (f *Fake) NodAps(et float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	if f.NodApsFunc != nil {
+		return f.NodApsFunc(et, pl, fl, m)
+	}
+
+	return nil, nil, nil, nil, nil
+}
+
+func (f *Fake) NodApsUT(ut float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	if f.NodApsUTFunc != nil {
+		return f.NodApsUTFunc(ut, pl, fl, m)
+	}
+
+	return nil, nil, nil, nil, nil
+}
+
+func (f *Fake) Pheno(et float64, pl Planet, fl *CalcFlags) (attr []float64, err error) {
+	if f.PhenoFunc != nil {
+		return f.PhenoFunc(et, pl, fl)
+	}
+
+	return nil, nil
+}
+
+func (f *Fake) PhenoUT(ut float64, pl Planet, fl *CalcFlags) (attr []float64, err error) {
+	if f.PhenoUTFunc != nil {
+		return f.PhenoUTFunc(ut, pl, fl)
+	}
+
+	return nil, nil
+}
+
+func (f *Fake) OrbitalElements(et float64, pl Planet, fl *CalcFlags) ([]float64, error) {
+	if f.OrbitalElementsFunc != nil {
+		return f.OrbitalElementsFunc(et, pl, fl)
+	}
+
+	return nil, nil
+}
+
+func (f *Fake) OrbitMaxMinTrueDistance(et float64, pl Planet, fl *CalcFlags) (dmax, dmin, dtrue float64, err error) {
+	if f.OrbitMaxMinTrueDistanceFunc != nil {
+		return f.OrbitMaxMinTrueDistanceFunc(et, pl, fl)
+	}
+
+	return 0, 0, 0, nil
+}
+
+func (f *Fake) SolCross(x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	if f.SolCrossFunc != nil {
+		return f.SolCrossFunc(x2cross, et, fl, backward)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) SolCrossUT(x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	if f.SolCrossUTFunc != nil {
+		return f.SolCrossUTFunc(x2cross, ut, fl, backward)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) MoonCross(x2cross, et float64, fl *CalcFlags) (float64, error) {
+	if f.MoonCrossFunc != nil {
+		return f.MoonCrossFunc(x2cross, et, fl)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) MoonCrossUT(x2cross, ut float64, fl *CalcFlags) (float64, error) {
+	if f.MoonCrossUTFunc != nil {
+		return f.MoonCrossUTFunc(x2cross, ut, fl)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) MoonCrossNode(et float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	if f.MoonCrossNodeFunc != nil {
+		return f.MoonCrossNodeFunc(et, fl)
+	}
+
+	return 0, 0, 0, nil
+}
+
+func (f *Fake) MoonCrossNodeUT(ut float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	if f.MoonCrossNodeUTFunc != nil {
+		return f.MoonCrossNodeUTFunc(ut, fl)
+	}
+
+	return 0, 0, 0, nil
+}
+
+func (f *Fake) HelioCross(pl Planet, x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	if f.HelioCrossFunc != nil {
+		return f.HelioCrossFunc(pl, x2cross, et, fl, backward)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) HelioCrossUT(pl Planet, x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	if f.HelioCrossUTFunc != nil {
+		return f.HelioCrossUTFunc(pl, x2cross, ut, fl, backward)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) GetAyanamsaEx(et float64, fl *AyanamsaExFlags) (float64, error) {
+	if f.GetAyanamsaExFunc != nil {
+		return f.GetAyanamsaExFunc(et, fl)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) GetAyanamsaExUT(ut float64, fl *AyanamsaExFlags) (float64, error) {
+	if f.GetAyanamsaExUTFunc != nil {
+		return f.GetAyanamsaExUTFunc(ut, fl)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) GetAyanamsaName(ayan Ayanamsa) (string, error) {
+	if f.GetAyanamsaNameFunc != nil {
+		return f.GetAyanamsaNameFunc(ayan)
+	}
+
+	return "", nil
+}
+
+func (f *Fake) JulDay(y, m, d int, h float64, ct CalType) (float64, error) {
+	if f.JulDayFunc != nil {
+		return f.JulDayFunc(y, m, d, h, ct)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) DateConversion(y, m, d int, h float64, ct CalType) (float64, error) {
+	if f.DateConversionFunc != nil {
+		return f.DateConversionFunc(y, m, d, h, ct)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) RevJul(jd float64, ct CalType) (y, m, d int, h float64, err error) {
+	if f.RevJulFunc != nil {
+		return f.RevJulFunc(jd, ct)
+	}
+
+	return 0, 0, 0, 0, nil
+}
+
+func (f *Fake) UTCToJD(y, m, d, h, i int, s float64, fl *DateConvertFlags) (et, ut float64, err error) {
+	if f.UTCToJDFunc != nil {
+		return f.UTCToJDFunc(y, m, d, h, i, s, fl)
+	}
+
+	return 0, 0, nil
+}
+
+func (f *Fake) JdETToUTC(et float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error) {
+	if f.JdETToUTCFunc != nil {
+		return f.JdETToUTCFunc(et, fl)
+	}
+
+	return 0, 0, 0, 0, 0, 0, nil
+}
+
+func (f *Fake) JdUT1ToUTC(ut1 float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error) {
+	if f.JdUT1ToUTCFunc != nil {
+		return f.JdUT1ToUTCFunc(ut1, fl)
+	}
+
+	return 0, 0, 0, 0, 0, 0, nil
+}
+
+func (f *Fake) DayOfWeek(jd float64) (int, error) {
+	if f.DayOfWeekFunc != nil {
+		return f.DayOfWeekFunc(jd)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) HousesEx(ut float64, fl *HousesExFlags, geo *GeoLoc, hsys HSys) ([]float64, []float64, error) {
+	if f.HousesExFunc != nil {
+		return f.HousesExFunc(ut, fl, geo, hsys)
+	}
+
+	return nil, nil, nil
+}
+
+func (f *Fake) HousesARMC(armc, geolat, eps float64, hsys HSys) ([]float64, []float64, error) {
+	if f.HousesARMCFunc != nil {
+		return f.HousesARMCFunc(armc, geolat, eps, hsys)
+	}
+
+	return nil, nil, nil
+}
+
+func (f *Fake) HousePos(armc, geolat, eps float64, hsys HSys, pllng, pllat float64) (float64, error) {
+	if f.HousePosFunc != nil {
+		return f.HousePosFunc(armc, geolat, eps, hsys, pllng, pllat)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) HouseName(hsys HSys) (string, error) {
+	if f.HouseNameFunc != nil {
+		return f.HouseNameFunc(hsys)
+	}
+
+	return "", nil
+}
+
+func (f *Fake) GauquelinSector(ut float64, pl Planet, star string, fl *CalcFlags, m GauquelinMethod, geo *GeoLoc, press, temp float64) (float64, error) {
+	if f.GauquelinSectorFunc != nil {
+		return f.GauquelinSectorFunc(ut, pl, star, fl, m, geo, press, temp)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) DeltaTEx(jd float64, eph Ephemeris) (float64, error) {
+	if f.DeltaTExFunc != nil {
+		return f.DeltaTExFunc(jd, eph)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) TimeEqu(jd float64, fl *TimeEquFlags) (float64, error) {
+	if f.TimeEquFunc != nil {
+		return f.TimeEquFunc(jd, fl)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) LMTToLAT(jdLMT, geolon float64, fl *TimeEquFlags) (float64, error) {
+	if f.LMTToLATFunc != nil {
+		return f.LMTToLATFunc(jdLMT, geolon, fl)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) LATToLMT(jdLAT, geolon float64, fl *TimeEquFlags) (float64, error) {
+	if f.LATToLMTFunc != nil {
+		return f.LATToLMTFunc(jdLAT, geolon, fl)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) SidTime0(ut, eps, nut float64, fl *SidTimeFlags) (float64, error) {
+	if f.SidTime0Func != nil {
+		return f.SidTime0Func(ut, eps, nut, fl)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) SidTime(ut float64, fl *SidTimeFlags) (float64, error) {
+	if f.SidTimeFunc != nil {
+		return f.SidTimeFunc(ut, fl)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) Azalt(ut float64, m AzaltMode, geo *GeoLoc, press, temp float64, in [3]float64, fl *AzaltFlags) ([]float64, error) {
+	if f.AzaltFunc != nil {
+		return f.AzaltFunc(ut, m, geo, press, temp, in, fl)
+	}
+
+	return nil, nil
+}
+
+func (f *Fake) AzaltRev(ut float64, m AzaltRevMode, geo *GeoLoc, in [2]float64, fl *AzaltFlags) ([]float64, error) {
+	if f.AzaltRevFunc != nil {
+		return f.AzaltRevFunc(ut, m, geo, in, fl)
+	}
+
+	return nil, nil
+}
+
+func (f *Fake) Refrac(alt, press, temp float64, m RefracMode) (float64, error) {
+	if f.RefracFunc != nil {
+		return f.RefracFunc(alt, press, temp, m)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) RefracExtended(alt, geoalt, press, temp, lapseRate float64, m RefracMode) (float64, []float64, error) {
+	if f.RefracExtendedFunc != nil {
+		return f.RefracExtendedFunc(alt, geoalt, press, temp, lapseRate, m)
+	}
+
+	return 0, nil, nil
+}
+
+func (f *Fake) RiseTrans(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp float64) (float64, error) {
+	if f.RiseTransFunc != nil {
+		return f.RiseTransFunc(ut, pl, star, fl, m, geo, press, temp)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) RiseTransTrueHor(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp, horhgt float64) (float64, error) {
+	if f.RiseTransTrueHorFunc != nil {
+		return f.RiseTransTrueHorFunc(ut, pl, star, fl, m, geo, press, temp, horhgt)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) HeliacalUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	if f.HeliacalUTFunc != nil {
+		return f.HeliacalUTFunc(ut, geo, atm, obs, obj, ev, fl)
+	}
+
+	return nil, nil
+}
+
+func (f *Fake) HeliacalPhenoUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	if f.HeliacalPhenoUTFunc != nil {
+		return f.HeliacalPhenoUTFunc(ut, geo, atm, obs, obj, ev, fl)
+	}
+
+	return nil, nil
+}
+
+func (f *Fake) VisLimitMag(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, fl *HeliacalFlags) (dret []float64, visible bool, err error) {
+	if f.VisLimitMagFunc != nil {
+		return f.VisLimitMagFunc(ut, geo, atm, obs, obj, fl)
+	}
+
+	return nil, false, nil
+}
+
+func (f *Fake) SolEclipseWhere(ut float64, fl *CalcFlags) (geo, attr []float64, typ EclType, err error) {
+	if f.SolEclipseWhereFunc != nil {
+		return f.SolEclipseWhereFunc(ut, fl)
+	}
+
+	return nil, nil, 0, nil
+}
+
+func (f *Fake) SolEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error) {
+	if f.SolEclipseHowFunc != nil {
+		return f.SolEclipseHowFunc(ut, fl, geo)
+	}
+
+	return nil, 0, nil
+}
+
+func (f *Fake) SolEclipseWhenGlob(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	if f.SolEclipseWhenGlobFunc != nil {
+		return f.SolEclipseWhenGlobFunc(ut, fl, typ, backward)
+	}
+
+	return nil, 0, nil
+}
+
+func (f *Fake) SolEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	if f.SolEclipseWhenLocFunc != nil {
+		return f.SolEclipseWhenLocFunc(ut, fl, geo, backward)
+	}
+
+	return nil, nil, 0, nil
+}
+
+func (f *Fake) LunEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error) {
+	if f.LunEclipseHowFunc != nil {
+		return f.LunEclipseHowFunc(ut, fl, geo)
+	}
+
+	return nil, 0, nil
+}
+
+func (f *Fake) LunEclipseWhen(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	if f.LunEclipseWhenFunc != nil {
+		return f.LunEclipseWhenFunc(ut, fl, typ, backward)
+	}
+
+	return nil, 0, nil
+}
+
+func (f *Fake) LunEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	if f.LunEclipseWhenLocFunc != nil {
+		return f.LunEclipseWhenLocFunc(ut, fl, geo, backward)
+	}
+
+	return nil, nil, 0, nil
+}
+
+func (f *Fake) LunOccultWhere(ut float64, pl Planet, star string, fl *CalcFlags) (geo, attr []float64, typ EclType, err error) {
+	if f.LunOccultWhereFunc != nil {
+		return f.LunOccultWhereFunc(ut, pl, star, fl)
+	}
+
+	return nil, nil, 0, nil
+}
+
+func (f *Fake) LunOccultWhenGlob(ut float64, pl Planet, star string, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	if f.LunOccultWhenGlobFunc != nil {
+		return f.LunOccultWhenGlobFunc(ut, pl, star, fl, typ, backward)
+	}
+
+	return nil, 0, nil
+}
+
+func (f *Fake) LunOccultWhenLoc(ut float64, pl Planet, star string, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	if f.LunOccultWhenLocFunc != nil {
+		return f.LunOccultWhenLocFunc(ut, pl, star, fl, geo, backward)
+	}
+
+	return nil, nil, 0, nil
+}
+
+func (f *Fake) Cotrans(in [3]float64, eps float64) ([]float64, error) {
+	if f.CotransFunc != nil {
+		return f.CotransFunc(in, eps)
+	}
+
+	return nil, nil
+}
+
+func (f *Fake) CotransSp(in [6]float64, eps float64) ([]float64, error) {
+	if f.CotransSpFunc != nil {
+		return f.CotransSpFunc(in, eps)
+	}
+
+	return nil, nil
+}
+
+func (f *Fake) DegNorm(x float64) (float64, error) {
+	if f.DegNormFunc != nil {
+		return f.DegNormFunc(x)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) RadNorm(x float64) (float64, error) {
+	if f.RadNormFunc != nil {
+		return f.RadNormFunc(x)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) DegMidp(x1, x0 float64) (float64, error) {
+	if f.DegMidpFunc != nil {
+		return f.DegMidpFunc(x1, x0)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) RadMidp(x1, x0 float64) (float64, error) {
+	if f.RadMidpFunc != nil {
+		return f.RadMidpFunc(x1, x0)
+	}
+
+	return 0, nil
+}
+
+func (f *Fake) SplitDeg(x float64, fl SplitDegFlags) (deg, min, sec int, secfr float64, sign int, err error) {
+	if f.SplitDegFunc != nil {
+		return f.SplitDegFunc(x, fl)
+	}
+
+	return 0, 0, 0, 0, 0, nil
+}
@@ -0,0 +1,66 @@
+package swego
+
+// ChartAngles calls HousesEx and returns the Ascendant, MC, ARMC, Vertex and
+// equatorial ascendant at ut for location loc. The angles do not depend on
+// house system hsys, but the error of HousesEx does: in polar regions house
+// systems like Placidus and Koch are not defined and err is warning
+// ErrHouseFallback, the returned angles are still valid.
+func ChartAngles(swe Interface, ut float64, loc *GeoLoc, hsys HSys) (asc, mc, armc, vertex, eqasc float64, err error) {
+	_, ascmc, err := swe.HousesEx(ut, nil, loc, hsys)
+	if len(ascmc) < 5 {
+		return 0, 0, 0, 0, 0, err
+	}
+
+	return ascmc[Asc], ascmc[MC], ascmc[ARMC], ascmc[Vertex], ascmc[EquAsc], err
+}
+
+// LocalARMC returns the ARMC (right ascension of the MC, also known as RAMC)
+// in degrees for the Julian Date ut in Universal Time at geographic longitude
+// long in degrees, positive east of Greenwich. It is LocalSiderealTime
+// converted from hours to degrees and can be passed as armc to HousesARMC and
+// HousePos.
+func LocalARMC(swe Interface, ut, long float64) (float64, error) {
+	lst, err := LocalSiderealTime(swe, ut, long)
+	return lst * 15, err
+}
+
+// HouseSystem is a house system and its name, see HouseSystems.
+type HouseSystem struct {
+	Code HSys
+	Name string
+}
+
+// HouseSystems returns the house systems supported by the C library behind
+// swe with the names returned by HouseName, in order of their code with
+// SunshineAlt following Sunshine. The codes are probed with HouseName, which
+// returns "Placidus" for unknown codes, so the list follows the version of the
+// library. Code 'A' is skipped as it is an alias of Equal.
+func HouseSystems(swe Interface) ([]HouseSystem, error) {
+	var systems []HouseSystem
+	probe := func(c HSys) error {
+		name, err := swe.HouseName(c)
+		if err != nil {
+			return err
+		}
+
+		if name != "" && (name != "Placidus" || c == Placidus) {
+			systems = append(systems, HouseSystem{Code: c, Name: name})
+		}
+
+		return nil
+	}
+
+	for c := HSys('B'); c <= 'Z'; c++ {
+		if err := probe(c); err != nil {
+			return nil, err
+		}
+
+		if c == Sunshine {
+			if err := probe(SunshineAlt); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return systems, nil
+}
@@ -0,0 +1,150 @@
+package swego
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unixEpochJD is the Julian Date of the Unix epoch, 1970-01-01 00:00:00.
+const unixEpochJD = 2440587.5
+
+// Constants of the Julian and Besselian epochs. A Julian epoch counts Julian
+// years of 365.25 days from J2000.0, a Besselian epoch counts tropical years
+// from B1900.0.
+const (
+	j2000         = 2451545.0
+	julianYear    = 365.25
+	b1900         = 2415020.31352
+	besselianYear = 365.242198781
+)
+
+// JulianDay is a Julian Date in Ephemeris Time as expected by functions like
+// Calc. It is distinct from JulianDayUT: the methods Calc and UT of each
+// type select the function and conversion of its time scale, so a value in
+// one time scale is not used where the other is expected. Convert it to a
+// float64 to pass it to other functions of the library.
+type JulianDay float64
+
+// JulianDayUT is a Julian Date in Universal Time as expected by functions
+// like CalcUT, see JulianDay.
+type JulianDayUT float64
+
+// Calc calls Calc of swe for jd.
+func (jd JulianDay) Calc(swe Interface, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	return swe.Calc(float64(jd), pl, fl)
+}
+
+// Calc calls CalcUT of swe for jd.
+func (jd JulianDayUT) Calc(swe Interface, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	return swe.CalcUT(float64(jd), pl, fl)
+}
+
+// ET returns jd converted to Ephemeris Time by adding the ΔT returned by
+// DeltaTEx for ephemeris eph. A warning of DeltaTEx is returned along with
+// the result.
+func (jd JulianDayUT) ET(swe Interface, eph Ephemeris) (JulianDay, error) {
+	dt, err := swe.DeltaTEx(float64(jd), eph)
+	if err != nil && !IsWarning(err) {
+		return 0, err
+	}
+
+	return JulianDay(float64(jd) + dt), err
+}
+
+// UT returns jd converted to Universal Time by subtracting ΔT. DeltaTEx
+// expects a date in Universal Time, so ΔT is evaluated at jd minus the ΔT at
+// jd, which is precise to far below a millisecond. A warning of DeltaTEx is
+// returned along with the result.
+func (jd JulianDay) UT(swe Interface, eph Ephemeris) (JulianDayUT, error) {
+	dt, err := swe.DeltaTEx(float64(jd), eph)
+	if err != nil && !IsWarning(err) {
+		return 0, err
+	}
+
+	dt, err = swe.DeltaTEx(float64(jd)-dt, eph)
+	if err != nil && !IsWarning(err) {
+		return 0, err
+	}
+
+	return JulianDayUT(float64(jd) - dt), err
+}
+
+// AddDays returns jd plus n days.
+func (jd JulianDay) AddDays(n float64) JulianDay { return jd + JulianDay(n) }
+
+// Time returns the date and time of jd in the proleptic Gregorian calendar as
+// a time.Time in UTC. The time is not converted to Universal Time, the clock
+// reading is that of Ephemeris Time.
+func (jd JulianDay) Time() time.Time { return jdTime(float64(jd)) }
+
+// String returns the date and time of jd, such as "2000-01-01 12:00:00 ET".
+func (jd JulianDay) String() string { return jdString(float64(jd), "ET") }
+
+// AddDays returns jd plus n days.
+func (jd JulianDayUT) AddDays(n float64) JulianDayUT { return jd + JulianDayUT(n) }
+
+// Time returns the date and time of jd in the proleptic Gregorian calendar as
+// a time.Time in UTC. The difference between UT1 and UTC of less than a
+// second and leap seconds are ignored, see TimeFromJulDay for an exact
+// conversion.
+func (jd JulianDayUT) Time() time.Time { return jdTime(float64(jd)) }
+
+// String returns the date and time of jd, such as "2000-01-01 12:00:00 UT".
+func (jd JulianDayUT) String() string { return jdString(float64(jd), "UT") }
+
+func jdTime(jd float64) time.Time {
+	s := (jd - unixEpochJD) * 86400
+	sec := math.Floor(s)
+	nsec := math.Round((s-sec)*1e6) * 1e3 // the precision of jd is about 40 µs
+	return time.Unix(int64(sec), int64(nsec)).UTC()
+}
+
+func jdString(jd float64, scale string) string {
+	return jdTime(jd).Format("2006-01-02 15:04:05 ") + scale
+}
+
+// JulianDayFromEpoch returns the Julian Date of epoch, a Julian epoch such as
+// "J2000.0" or "J1991.25" or a Besselian epoch such as "B1950.0". Julian
+// epochs count Julian years of 365.25 days from J2000.0 (JD 2451545.0).
+// Besselian epochs count tropical years of 365.242198781 days from B1900.0
+// (JD 2415020.31352).
+func JulianDayFromEpoch(epoch string) (float64, error) {
+	invalid := Error{Code: -1, Message: "invalid epoch: " + strconv.Quote(epoch)}
+	if len(epoch) < 2 {
+		return 0, invalid
+	}
+
+	y, err := strconv.ParseFloat(epoch[1:], 64)
+	if err != nil || strings.ContainsAny(epoch[1:], "eEpP+xX") || math.IsInf(y, 0) || math.IsNaN(y) {
+		return 0, invalid
+	}
+
+	switch epoch[0] {
+	case 'J':
+		return j2000 + (y-2000)*julianYear, nil
+	case 'B':
+		return b1900 + (y-1900)*besselianYear, nil
+	}
+
+	return 0, invalid
+}
+
+// EpochFromJulianDay returns the Julian epoch of Julian Date jd, or the
+// Besselian epoch if besselian is true, in the format accepted by
+// JulianDayFromEpoch. The year is rounded to 6 decimals.
+func EpochFromJulianDay(jd float64, besselian bool) string {
+	prefix, y := "J", 2000+(jd-j2000)/julianYear
+	if besselian {
+		prefix, y = "B", 1900+(jd-b1900)/besselianYear
+	}
+
+	s := strconv.FormatFloat(y, 'f', 6, 64)
+	s = strings.TrimRight(s, "0")
+	if strings.HasSuffix(s, ".") {
+		s += "0"
+	}
+
+	return prefix + s
+}
@@ -0,0 +1,175 @@
+package swego
+
+import "math"
+
+// moonPhaseNames are the names of the phases of the Moon by octant of the
+// elongation of the Moon from the Sun, starting at New Moon.
+var moonPhaseNames = [8]string{
+	"New Moon",
+	"Waxing Crescent",
+	"First Quarter",
+	"Waxing Gibbous",
+	"Full Moon",
+	"Waning Gibbous",
+	"Last Quarter",
+	"Waning Crescent",
+}
+
+// MoonPhase returns the illuminated fraction of the disc of the Moon as
+// computed by PhenoUT and the name of the phase of the Moon at ut. The phase
+// is classified by the elongation of the Moon east of the Sun in ecliptic
+// longitude, which is below 180° for the waxing Moon and above 180° for the
+// waning Moon. The principal phases New Moon, First Quarter, Full Moon and
+// Last Quarter cover an elongation of ±22.5° around 0°, 90°, 180° and 270°,
+// the intermediate phases like Waxing Crescent cover the remainder.
+func MoonPhase(swe Interface, ut float64, fl *CalcFlags) (illumination float64, phaseName string, err error) {
+	attr, err := swe.PhenoUT(ut, Moon, fl)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if len(attr) < 5 {
+		return 0, "", ErrShortResult
+	}
+
+	elong, err := moonElongation(swe, ut, fl)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return NewPhenoData(attr).Phase, moonPhaseNames[int(degNorm(elong+22.5)/45)%8], nil
+}
+
+// moonElongation returns the elongation of the Moon east of the Sun in
+// ecliptic longitude at ut in the range 0 <= elong < 360.
+func moonElongation(swe Interface, ut float64, fl *CalcFlags) (float64, error) {
+	sun, _, err := swe.CalcUT(ut, Sun, fl)
+	if err != nil && !IsWarning(err) {
+		return 0, err
+	}
+
+	moon, _, err := swe.CalcUT(ut, Moon, fl)
+	if err != nil && !IsWarning(err) {
+		return 0, err
+	}
+
+	if len(sun) < 1 || len(moon) < 1 {
+		return 0, ErrShortResult
+	}
+
+	return degNorm(moon[0] - sun[0]), nil
+}
+
+// LunarPhase is the type of the principal phases of the Moon. The value is
+// the elongation of the Moon east of the Sun in degrees.
+type LunarPhase int
+
+// Principal phases of the Moon.
+const (
+	NewMoon      LunarPhase = 0
+	FirstQuarter LunarPhase = 90
+	FullMoon     LunarPhase = 180
+	LastQuarter  LunarPhase = 270
+)
+
+// lunarPhaseMaxIter is the maximum number of iterations of NextLunarPhase.
+const lunarPhaseMaxIter = 20
+
+// ErrNoConvergence is returned by NextLunarPhase if the search does not
+// converge within the maximum number of iterations.
+var ErrNoConvergence = Error{Code: -1, Message: "no convergence in crossing search"}
+
+// NextLunarPhase returns the Julian Date (in Universal Time) of the first
+// phase phase of the Moon after jdStart (in Universal Time). The search
+// proceeds forward in time only. The Moon is moved to the longitude of the
+// Sun plus the elongation of the phase with MoonCrossUT, which is repeated
+// with the longitude of the Sun at the found time until the time changes by
+// less than 0.01 seconds. The longitudes are computed with calculation flags
+// fl. It returns ErrNoConvergence if the time does not converge.
+func NextLunarPhase(swe Interface, jdStart float64, phase LunarPhase, fl *CalcFlags) (float64, error) {
+	jd := jdStart
+	for i := 0; i < lunarPhaseMaxIter; i++ {
+		sun, _, err := swe.CalcUT(jd, Sun, fl)
+		if err != nil && !IsWarning(err) {
+			return 0, err
+		}
+
+		if len(sun) < 1 {
+			return 0, ErrShortResult
+		}
+
+		next, err := swe.MoonCrossUT(degNorm(sun[0]+float64(phase)), jd, fl)
+		if err != nil {
+			return 0, err
+		}
+
+		if next-jd < 1e-7 {
+			return next, nil
+		}
+
+		jd = next
+	}
+
+	return 0, ErrNoConvergence
+}
+
+// Bodies and aspects considered by VoidOfCourseMoon.
+var (
+	voidOfCourseBodies  = [...]Planet{Sun, Mercury, Venus, Mars, Jupiter, Saturn}
+	voidOfCourseAspects = [...]float64{0, 60, 90, 120, 180, 240, 270, 300}
+)
+
+// voidOfCourseLookBack is the period in days searched for the last aspect of
+// the Moon before its sign change, it is longer than the Moon takes to
+// traverse a sign.
+const voidOfCourseLookBack = 3
+
+// VoidOfCourseMoon returns the Julian Date (in Universal Time) of the first
+// sign change of the Moon after jdStart (in Universal Time) and of the last
+// aspect of the Moon before that sign change. The Moon is void of course from
+// lastAspectJD to signChangeJD, it is void at jdStart if lastAspectJD is
+// before jdStart. Only the Ptolemaic aspects conjunction, sextile, square,
+// trine and opposition to the traditional planets Sun, Mercury, Venus, Mars,
+// Jupiter and Saturn are considered. The last aspect may be formed in the
+// sign before the current sign of the Moon. The sign change is found with
+// MoonCrossUT and the aspects with NextAspect, so the longitudes are computed
+// with calculation flags fl.
+func VoidOfCourseMoon(swe Interface, jdStart float64, fl *CalcFlags) (lastAspectJD, signChangeJD float64, err error) {
+	moon, _, err := swe.CalcUT(jdStart, Moon, fl)
+	if err != nil && !IsWarning(err) {
+		return 0, 0, err
+	}
+
+	if len(moon) < 1 {
+		return 0, 0, ErrShortResult
+	}
+
+	sign := math.Floor(moon[0] / 30)
+	signChangeJD, err = swe.MoonCrossUT(degNorm((sign+1)*30), jdStart, fl)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Each aspect to a body is formed about once a month, so within the
+	// look back period there is at most one of each.
+	for start := signChangeJD - voidOfCourseLookBack; start > signChangeJD-10*voidOfCourseLookBack; start -= voidOfCourseLookBack {
+		for _, pl := range voidOfCourseBodies {
+			for _, asp := range voidOfCourseAspects {
+				jd, err := NextAspect(swe, start, Moon, pl, asp, fl)
+				if err != nil {
+					return 0, 0, err
+				}
+
+				if jd < signChangeJD && jd > lastAspectJD {
+					lastAspectJD = jd
+				}
+			}
+		}
+
+		if lastAspectJD != 0 {
+			return lastAspectJD, signChangeJD, nil
+		}
+	}
+
+	return 0, signChangeJD, ErrNoAspect
+}
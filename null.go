@@ -0,0 +1,719 @@
+package swego
+
+import "sync"
+
+// Null is a library handle that implements every function by returning zero
+// values and a nil error without calling into the C library. It is intended
+// for tests of code that depends on Interface, which can then run without the
+// C library and ephemeris files.
+type Null struct{}
+
+var _ Interface = Null{} // assert interface
+
+// A Call represents a function call recorded by a Recorder.
+type Call struct {
+	Name string        // function name
+	Args []interface{} // arguments of the call
+}
+
+// Recorder is a library handle that records all function calls before
+// passing them to the underlying handle. It is intended for tests and is safe
+// for concurrent use if the underlying handle is.
+type Recorder struct {
+	swe Interface
+	log *callLog
+}
+
+type callLog struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewRecorder returns a Recorder that passes the calls to swe. If swe is nil,
+// Null is used.
+func NewRecorder(swe Interface) *Recorder {
+	if swe == nil {
+		swe = Null{}
+	}
+
+	return &Recorder{swe: swe, log: new(callLog)}
+}
+
+// Calls returns the calls recorded so far in order.
+func (r *Recorder) Calls() []Call {
+	r.log.mu.Lock()
+	calls := make([]Call, len(r.log.calls))
+	copy(calls, r.log.calls)
+	r.log.mu.Unlock()
+	return calls
+}
+
+// Reset removes all recorded calls.
+func (r *Recorder) Reset() {
+	r.log.mu.Lock()
+	r.log.calls = nil
+	r.log.mu.Unlock()
+}
+
+func (r *Recorder) record(name string, args ...interface{}) {
+	r.log.mu.Lock()
+	r.log.calls = append(r.log.calls, Call{name, args})
+	r.log.mu.Unlock()
+}
+
+// ExclusiveLock exclusively locks the underlying handle if it implements
+// ExclusiveLocker. The calls to the locked handle are recorded by r.
+func (r *Recorder) ExclusiveLock() LockedInterface {
+	var li LockedInterface = nopLocked{r.swe}
+	if l, ok := r.swe.(ExclusiveLocker); ok {
+		li = l.ExclusiveLock()
+	}
+
+	return recorderLocked{&Recorder{swe: li, log: r.log}, li}
+}
+
+type recorderLocked struct {
+	*Recorder
+	inner LockedInterface
+}
+
+func (l recorderLocked) ExclusiveUnlock() { l.inner.ExclusiveUnlock() }
+
+func (Null) Version() (string, error) {
+	return "", nil
+}
+
+func (Null) PlanetName(pl Planet) (string, error) {
+	return "", nil
+}
+
+func (Null) Calc(et float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	return nil, 0, nil
+}
+
+func (Null) CalcUT(ut float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	return nil, 0, nil
+}
+
+func (Null) CalcPctr(et float64, pl, plctr Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	return nil, 0, nil
+}
+
+func (Null) FixStar(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	return "", nil, 0, nil
+}
+
+func (Null) FixStarUT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	return "", nil, 0, nil
+}
+
+func (Null) FixStar2(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	return "", nil, 0, nil
+}
+
+func (Null) FixStar2UT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	return "", nil, 0, nil
+}
+
+func (Null) FixStarMag(star string) (name string, mag float64, err error) {
+	return "", 0, nil
+}
+
+func (Null) NodAps(et float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	return nil, nil, nil, nil, nil
+}
+
+func (Null) NodApsUT(ut float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	return nil, nil, nil, nil, nil
+}
+
+func (Null) Pheno(et float64, pl Planet, fl *CalcFlags) (attr []float64, err error) {
+	return nil, nil
+}
+
+func (Null) PhenoUT(ut float64, pl Planet, fl *CalcFlags) (attr []float64, err error) {
+	return nil, nil
+}
+
+func (Null) OrbitalElements(et float64, pl Planet, fl *CalcFlags) ([]float64, error) {
+	return nil, nil
+}
+
+func (Null) OrbitMaxMinTrueDistance(et float64, pl Planet, fl *CalcFlags) (dmax, dmin, dtrue float64, err error) {
+	return 0, 0, 0, nil
+}
+
+func (Null) SolCross(x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	return 0, nil
+}
+
+func (Null) SolCrossUT(x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	return 0, nil
+}
+
+func (Null) MoonCross(x2cross, et float64, fl *CalcFlags) (float64, error) {
+	return 0, nil
+}
+
+func (Null) MoonCrossUT(x2cross, ut float64, fl *CalcFlags) (float64, error) {
+	return 0, nil
+}
+
+func (Null) MoonCrossNode(et float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	return 0, 0, 0, nil
+}
+
+func (Null) MoonCrossNodeUT(ut float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	return 0, 0, 0, nil
+}
+
+func (Null) HelioCross(pl Planet, x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	return 0, nil
+}
+
+func (Null) HelioCrossUT(pl Planet, x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	return 0, nil
+}
+
+func (Null) GetAyanamsaEx(et float64, fl *AyanamsaExFlags) (float64, error) {
+	return 0, nil
+}
+
+func (Null) GetAyanamsaExUT(ut float64, fl *AyanamsaExFlags) (float64, error) {
+	return 0, nil
+}
+
+func (Null) GetAyanamsaName(ayan Ayanamsa) (string, error) {
+	return "", nil
+}
+
+func (Null) JulDay(y, m, d int, h float64, ct CalType) (float64, error) {
+	return 0, nil
+}
+
+func (Null) DateConversion(y, m, d int, h float64, ct CalType) (float64, error) {
+	return 0, nil
+}
+
+func (Null) RevJul(jd float64, ct CalType) (y, m, d int, h float64, err error) {
+	return 0, 0, 0, 0, nil
+}
+
+func (Null) UTCToJD(y, m, d, h, i int, s float64, fl *DateConvertFlags) (et, ut float64, err error) {
+	return 0, 0, nil
+}
+
+func (Null) JdETToUTC(et float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error) {
+	return 0, 0, 0, 0, 0, 0, nil
+}
+
+func (Null) JdUT1ToUTC(ut1 float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error) {
+	return 0, 0, 0, 0, 0, 0, nil
+}
+
+func (Null) DayOfWeek(jd float64) (int, error) {
+	return 0, nil
+}
+
+func (Null) HousesEx(ut float64, fl *HousesExFlags, geo *GeoLoc, hsys HSys) ([]float64, []float64, error) {
+	return nil, nil, nil
+}
+
+func (Null) HousesARMC(armc, geolat, eps float64, hsys HSys) ([]float64, []float64, error) {
+	return nil, nil, nil
+}
+
+func (Null) HousePos(armc, geolat, eps float64, hsys HSys, pllng, pllat float64) (float64, error) {
+	return 0, nil
+}
+
+func (Null) HouseName(hsys HSys) (string, error) {
+	return "", nil
+}
+
+func (Null) GauquelinSector(ut float64, pl Planet, star string, fl *CalcFlags, m GauquelinMethod, geo *GeoLoc, press, temp float64) (float64, error) {
+	return 0, nil
+}
+
+func (Null) DeltaTEx(jd float64, eph Ephemeris) (float64, error) {
+	return 0, nil
+}
+
+func (Null) TimeEqu(jd float64, fl *TimeEquFlags) (float64, error) {
+	return 0, nil
+}
+
+func (Null) LMTToLAT(jdLMT, geolon float64, fl *TimeEquFlags) (float64, error) {
+	return 0, nil
+}
+
+func (Null) LATToLMT(jdLAT, geolon float64, fl *TimeEquFlags) (float64, error) {
+	return 0, nil
+}
+
+func (Null) SidTime0(ut, eps, nut float64, fl *SidTimeFlags) (float64, error) {
+	return 0, nil
+}
+
+func (Null) SidTime(ut float64, fl *SidTimeFlags) (float64, error) {
+	return 0, nil
+}
+
+func (Null) Azalt(ut float64, m AzaltMode, geo *GeoLoc, press, temp float64, in [3]float64, fl *AzaltFlags) ([]float64, error) {
+	return nil, nil
+}
+
+func (Null) AzaltRev(ut float64, m AzaltRevMode, geo *GeoLoc, in [2]float64, fl *AzaltFlags) ([]float64, error) {
+	return nil, nil
+}
+
+func (Null) Refrac(alt, press, temp float64, m RefracMode) (float64, error) {
+	return 0, nil
+}
+
+func (Null) RefracExtended(alt, geoalt, press, temp, lapseRate float64, m RefracMode) (float64, []float64, error) {
+	return 0, nil, nil
+}
+
+func (Null) RiseTrans(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp float64) (float64, error) {
+	return 0, nil
+}
+
+func (Null) RiseTransTrueHor(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp, horhgt float64) (float64, error) {
+	return 0, nil
+}
+
+func (Null) HeliacalUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	return nil, nil
+}
+
+func (Null) HeliacalPhenoUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	return nil, nil
+}
+
+func (Null) VisLimitMag(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, fl *HeliacalFlags) (dret []float64, visible bool, err error) {
+	return nil, false, nil
+}
+
+func (Null) SolEclipseWhere(ut float64, fl *CalcFlags) (geo, attr []float64, typ EclType, err error) {
+	return nil, nil, 0, nil
+}
+
+func (Null) SolEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error) {
+	return nil, 0, nil
+}
+
+func (Null) SolEclipseWhenGlob(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	return nil, 0, nil
+}
+
+func (Null) SolEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	return nil, nil, 0, nil
+}
+
+func (Null) LunEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error) {
+	return nil, 0, nil
+}
+
+func (Null) LunEclipseWhen(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	return nil, 0, nil
+}
+
+func (Null) LunEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	return nil, nil, 0, nil
+}
+
+func (Null) LunOccultWhere(ut float64, pl Planet, star string, fl *CalcFlags) (geo, attr []float64, typ EclType, err error) {
+	return nil, nil, 0, nil
+}
+
+func (Null) LunOccultWhenGlob(ut float64, pl Planet, star string, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	return nil, 0, nil
+}
+
+func (Null) LunOccultWhenLoc(ut float64, pl Planet, star string, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	return nil, nil, 0, nil
+}
+
+func (Null) Cotrans(in [3]float64, eps float64) ([]float64, error) {
+	return nil, nil
+}
+
+func (Null) CotransSp(in [6]float64, eps float64) ([]float64, error) {
+	return nil, nil
+}
+
+func (Null) DegNorm(x float64) (float64, error) {
+	return 0, nil
+}
+
+func (Null) RadNorm(x float64) (float64, error) {
+	return 0, nil
+}
+
+func (Null) DegMidp(x1, x0 float64) (float64, error) {
+	return 0, nil
+}
+
+func (Null) RadMidp(x1, x0 float64) (float64, error) {
+	return 0, nil
+}
+
+func (Null) SplitDeg(x float64, fl SplitDegFlags) (deg, min, sec int, secfr float64, sign int, err error) {
+	return 0, 0, 0, 0, 0, nil
+}
+
+func (r *Recorder) Version() (string, error) {
+	r.record("Version")
+	return r.swe.Version()
+}
+
+func (r *Recorder) PlanetName(pl Planet) (string, error) {
+	r.record("PlanetName", pl)
+	return r.swe.PlanetName(pl)
+}
+
+func (r *Recorder) Calc(et float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	r.record("Calc", et, pl, fl)
+	return r.swe.Calc(et, pl, fl)
+}
+
+func (r *Recorder) CalcUT(ut float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	r.record("CalcUT", ut, pl, fl)
+	return r.swe.CalcUT(ut, pl, fl)
+}
+
+func (r *Recorder) CalcPctr(et float64, pl, plctr Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	r.record("CalcPctr", et, pl, plctr, fl)
+	return r.swe.CalcPctr(et, pl, plctr, fl)
+}
+
+func (r *Recorder) FixStar(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	r.record("FixStar", star, et, fl)
+	return r.swe.FixStar(star, et, fl)
+}
+
+func (r *Recorder) FixStarUT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	r.record("FixStarUT", star, ut, fl)
+	return r.swe.FixStarUT(star, ut, fl)
+}
+
+func (r *Recorder) FixStar2(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	r.record("FixStar2", star, et, fl)
+	return r.swe.FixStar2(star, et, fl)
+}
+
+func (r *Recorder) FixStar2UT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	r.record("FixStar2UT", star, ut, fl)
+	return r.swe.FixStar2UT(star, ut, fl)
+}
+
+func (r *Recorder) FixStarMag(star string) (name string, mag float64, err error) {
+	r.record("FixStarMag", star)
+	return r.swe.FixStarMag(star)
+}
+
+func (r *Recorder) NodAps(et float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	r.record("NodAps", et, pl, fl, m)
+	return r.swe.NodAps(et, pl, fl, m)
+}
+
+func (r *Recorder) NodApsUT(ut float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	r.record("NodApsUT", ut, pl, fl, m)
+	return r.swe.NodApsUT(ut, pl, fl, m)
+}
+
+func (r *Recorder) Pheno(et float64, pl Planet, fl *CalcFlags) (attr []float64, err error) {
+	r.record("Pheno", et, pl, fl)
+	return r.swe.Pheno(et, pl, fl)
+}
+
+func (r *Recorder) PhenoUT(ut float64, pl Planet, fl *CalcFlags) (attr []float64, err error) {
+	r.record("PhenoUT", ut, pl, fl)
+	return r.swe.PhenoUT(ut, pl, fl)
+}
+
+func (r *Recorder) OrbitalElements(et float64, pl Planet, fl *CalcFlags) ([]float64, error) {
+	r.record("OrbitalElements", et, pl, fl)
+	return r.swe.OrbitalElements(et, pl, fl)
+}
+
+func (r *Recorder) OrbitMaxMinTrueDistance(et float64, pl Planet, fl *CalcFlags) (dmax, dmin, dtrue float64, err error) {
+	r.record("OrbitMaxMinTrueDistance", et, pl, fl)
+	return r.swe.OrbitMaxMinTrueDistance(et, pl, fl)
+}
+
+func (r *Recorder) SolCross(x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	r.record("SolCross", x2cross, et, fl, backward)
+	return r.swe.SolCross(x2cross, et, fl, backward)
+}
+
+func (r *Recorder) SolCrossUT(x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	r.record("SolCrossUT", x2cross, ut, fl, backward)
+	return r.swe.SolCrossUT(x2cross, ut, fl, backward)
+}
+
+func (r *Recorder) MoonCross(x2cross, et float64, fl *CalcFlags) (float64, error) {
+	r.record("MoonCross", x2cross, et, fl)
+	return r.swe.MoonCross(x2cross, et, fl)
+}
+
+func (r *Recorder) MoonCrossUT(x2cross, ut float64, fl *CalcFlags) (float64, error) {
+	r.record("MoonCrossUT", x2cross, ut, fl)
+	return r.swe.MoonCrossUT(x2cross, ut, fl)
+}
+
+func (r *Recorder) MoonCrossNode(et float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	r.record("MoonCrossNode", et, fl)
+	return r.swe.MoonCrossNode(et, fl)
+}
+
+func (r *Recorder) MoonCrossNodeUT(ut float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	r.record("MoonCrossNodeUT", ut, fl)
+	return r.swe.MoonCrossNodeUT(ut, fl)
+}
+
+func (r *Recorder) HelioCross(pl Planet, x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	r.record("HelioCross", pl, x2cross, et, fl, backward)
+	return r.swe.HelioCross(pl, x2cross, et, fl, backward)
+}
+
+func (r *Recorder) HelioCrossUT(pl Planet, x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	r.record("HelioCrossUT", pl, x2cross, ut, fl, backward)
+	return r.swe.HelioCrossUT(pl, x2cross, ut, fl, backward)
+}
+
+func (r *Recorder) GetAyanamsaEx(et float64, fl *AyanamsaExFlags) (float64, error) {
+	r.record("GetAyanamsaEx", et, fl)
+	return r.swe.GetAyanamsaEx(et, fl)
+}
+
+func (r *Recorder) GetAyanamsaExUT(ut float64, fl *AyanamsaExFlags) (float64, error) {
+	r.record("GetAyanamsaExUT", ut, fl)
+	return r.swe.GetAyanamsaExUT(ut, fl)
+}
+
+func (r *Recorder) GetAyanamsaName(ayan Ayanamsa) (string, error) {
+	r.record("GetAyanamsaName", ayan)
+	return r.swe.GetAyanamsaName(ayan)
+}
+
+func (r *Recorder) JulDay(y, m, d int, h float64, ct CalType) (float64, error) {
+	r.record("JulDay", y, m, d, h, ct)
+	return r.swe.JulDay(y, m, d, h, ct)
+}
+
+func (r *Recorder) DateConversion(y, m, d int, h float64, ct CalType) (float64, error) {
+	r.record("DateConversion", y, m, d, h, ct)
+	return r.swe.DateConversion(y, m, d, h, ct)
+}
+
+func (r *Recorder) RevJul(jd float64, ct CalType) (y, m, d int, h float64, err error) {
+	r.record("RevJul", jd, ct)
+	return r.swe.RevJul(jd, ct)
+}
+
+func (r *Recorder) UTCToJD(y, m, d, h, i int, s float64, fl *DateConvertFlags) (et, ut float64, err error) {
+	r.record("UTCToJD", y, m, d, h, i, s, fl)
+	return r.swe.UTCToJD(y, m, d, h, i, s, fl)
+}
+
+func (r *Recorder) JdETToUTC(et float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error) {
+	r.record("JdETToUTC", et, fl)
+	return r.swe.JdETToUTC(et, fl)
+}
+
+func (r *Recorder) JdUT1ToUTC(ut1 float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error) {
+	r.record("JdUT1ToUTC", ut1, fl)
+	return r.swe.JdUT1ToUTC(ut1, fl)
+}
+
+func (r *Recorder) DayOfWeek(jd float64) (int, error) {
+	r.record("DayOfWeek", jd)
+	return r.swe.DayOfWeek(jd)
+}
+
+func (r *Recorder) HousesEx(ut float64, fl *HousesExFlags, geo *GeoLoc, hsys HSys) ([]float64, []float64, error) {
+	r.record("HousesEx", ut, fl, geo, hsys)
+	return r.swe.HousesEx(ut, fl, geo, hsys)
+}
+
+func (r *Recorder) HousesARMC(armc, geolat, eps float64, hsys HSys) ([]float64, []float64, error) {
+	r.record("HousesARMC", armc, geolat, eps, hsys)
+	return r.swe.HousesARMC(armc, geolat, eps, hsys)
+}
+
+func (r *Recorder) HousePos(armc, geolat, eps float64, hsys HSys, pllng, pllat float64) (float64, error) {
+	r.record("HousePos", armc, geolat, eps, hsys, pllng, pllat)
+	return r.swe.HousePos(armc, geolat, eps, hsys, pllng, pllat)
+}
+
+func (r *Recorder) HouseName(hsys HSys) (string, error) {
+	r.record("HouseName", hsys)
+	return r.swe.HouseName(hsys)
+}
+
+func (r *Recorder) GauquelinSector(ut float64, pl Planet, star string, fl *CalcFlags, m GauquelinMethod, geo *GeoLoc, press, temp float64) (float64, error) {
+	r.record("GauquelinSector", ut, pl, star, fl, m, geo, press, temp)
+	return r.swe.GauquelinSector(ut, pl, star, fl, m, geo, press, temp)
+}
+
+func (r *Recorder) DeltaTEx(jd float64, eph Ephemeris) (float64, error) {
+	r.record("DeltaTEx", jd, eph)
+	return r.swe.DeltaTEx(jd, eph)
+}
+
+func (r *Recorder) TimeEqu(jd float64, fl *TimeEquFlags) (float64, error) {
+	r.record("TimeEqu", jd, fl)
+	return r.swe.TimeEqu(jd, fl)
+}
+
+func (r *Recorder) LMTToLAT(jdLMT, geolon float64, fl *TimeEquFlags) (float64, error) {
+	r.record("LMTToLAT", jdLMT, geolon, fl)
+	return r.swe.LMTToLAT(jdLMT, geolon, fl)
+}
+
+func (r *Recorder) LATToLMT(jdLAT, geolon float64, fl *TimeEquFlags) (float64, error) {
+	r.record("LATToLMT", jdLAT, geolon, fl)
+	return r.swe.LATToLMT(jdLAT, geolon, fl)
+}
+
+func (r *Recorder) SidTime0(ut, eps, nut float64, fl *SidTimeFlags) (float64, error) {
+	r.record("SidTime0", ut, eps, nut, fl)
+	return r.swe.SidTime0(ut, eps, nut, fl)
+}
+
+func (r *Recorder) SidTime(ut float64, fl *SidTimeFlags) (float64, error) {
+	r.record("SidTime", ut, fl)
+	return r.swe.SidTime(ut, fl)
+}
+
+func (r *Recorder) Azalt(ut float64, m AzaltMode, geo *GeoLoc, press, temp float64, in [3]float64, fl *AzaltFlags) ([]float64, error) {
+	r.record("Azalt", ut, m, geo, press, temp, in, fl)
+	return r.swe.Azalt(ut, m, geo, press, temp, in, fl)
+}
+
+func (r *Recorder) AzaltRev(ut float64, m AzaltRevMode, geo *GeoLoc, in [2]float64, fl *AzaltFlags) ([]float64, error) {
+	r.record("AzaltRev", ut, m, geo, in, fl)
+	return r.swe.AzaltRev(ut, m, geo, in, fl)
+}
+
+func (r *Recorder) Refrac(alt, press, temp float64, m RefracMode) (float64, error) {
+	r.record("Refrac", alt, press, temp, m)
+	return r.swe.Refrac(alt, press, temp, m)
+}
+
+func (r *Recorder) RefracExtended(alt, geoalt, press, temp, lapseRate float64, m RefracMode) (float64, []float64, error) {
+	r.record("RefracExtended", alt, geoalt, press, temp, lapseRate, m)
+	return r.swe.RefracExtended(alt, geoalt, press, temp, lapseRate, m)
+}
+
+func (r *Recorder) RiseTrans(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp float64) (float64, error) {
+	r.record("RiseTrans", ut, pl, star, fl, m, geo, press, temp)
+	return r.swe.RiseTrans(ut, pl, star, fl, m, geo, press, temp)
+}
+
+func (r *Recorder) RiseTransTrueHor(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp, horhgt float64) (float64, error) {
+	r.record("RiseTransTrueHor", ut, pl, star, fl, m, geo, press, temp, horhgt)
+	return r.swe.RiseTransTrueHor(ut, pl, star, fl, m, geo, press, temp, horhgt)
+}
+
+func (r *Recorder) HeliacalUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	r.record("HeliacalUT", ut, geo, atm, obs, obj, ev, fl)
+	return r.swe.HeliacalUT(ut, geo, atm, obs, obj, ev, fl)
+}
+
+func (r *Recorder) HeliacalPhenoUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	r.record("HeliacalPhenoUT", ut, geo, atm, obs, obj, ev, fl)
+	return r.swe.HeliacalPhenoUT(ut, geo, atm, obs, obj, ev, fl)
+}
+
+func (r *Recorder) VisLimitMag(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, fl *HeliacalFlags) (dret []float64, visible bool, err error) {
+	r.record("VisLimitMag", ut, geo, atm, obs, obj, fl)
+	return r.swe.VisLimitMag(ut, geo, atm, obs, obj, fl)
+}
+
+func (r *Recorder) SolEclipseWhere(ut float64, fl *CalcFlags) (geo, attr []float64, typ EclType, err error) {
+	r.record("SolEclipseWhere", ut, fl)
+	return r.swe.SolEclipseWhere(ut, fl)
+}
+
+func (r *Recorder) SolEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error) {
+	r.record("SolEclipseHow", ut, fl, geo)
+	return r.swe.SolEclipseHow(ut, fl, geo)
+}
+
+func (r *Recorder) SolEclipseWhenGlob(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	r.record("SolEclipseWhenGlob", ut, fl, typ, backward)
+	return r.swe.SolEclipseWhenGlob(ut, fl, typ, backward)
+}
+
+func (r *Recorder) SolEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	r.record("SolEclipseWhenLoc", ut, fl, geo, backward)
+	return r.swe.SolEclipseWhenLoc(ut, fl, geo, backward)
+}
+
+func (r *Recorder) LunEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error) {
+	r.record("LunEclipseHow", ut, fl, geo)
+	return r.swe.LunEclipseHow(ut, fl, geo)
+}
+
+func (r *Recorder) LunEclipseWhen(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	r.record("LunEclipseWhen", ut, fl, typ, backward)
+	return r.swe.LunEclipseWhen(ut, fl, typ, backward)
+}
+
+func (r *Recorder) LunEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	r.record("LunEclipseWhenLoc", ut, fl, geo, backward)
+	return r.swe.LunEclipseWhenLoc(ut, fl, geo, backward)
+}
+
+func (r *Recorder) LunOccultWhere(ut float64, pl Planet, star string, fl *CalcFlags) (geo, attr []float64, typ EclType, err error) {
+	r.record("LunOccultWhere", ut, pl, star, fl)
+	return r.swe.LunOccultWhere(ut, pl, star, fl)
+}
+
+func (r *Recorder) LunOccultWhenGlob(ut float64, pl Planet, star string, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	r.record("LunOccultWhenGlob", ut, pl, star, fl, typ, backward)
+	return r.swe.LunOccultWhenGlob(ut, pl, star, fl, typ, backward)
+}
+
+func (r *Recorder) LunOccultWhenLoc(ut float64, pl Planet, star string, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	r.record("LunOccultWhenLoc", ut, pl, star, fl, geo, backward)
+	return r.swe.LunOccultWhenLoc(ut, pl, star, fl, geo, backward)
+}
+
+func (r *Recorder) Cotrans(in [3]float64, eps float64) ([]float64, error) {
+	r.record("Cotrans", in, eps)
+	return r.swe.Cotrans(in, eps)
+}
+
+func (r *Recorder) CotransSp(in [6]float64, eps float64) ([]float64, error) {
+	r.record("CotransSp", in, eps)
+	return r.swe.CotransSp(in, eps)
+}
+
+func (r *Recorder) DegNorm(x float64) (float64, error) {
+	r.record("DegNorm", x)
+	return r.swe.DegNorm(x)
+}
+
+func (r *Recorder) RadNorm(x float64) (float64, error) {
+	r.record("RadNorm", x)
+	return r.swe.RadNorm(x)
+}
+
+func (r *Recorder) DegMidp(x1, x0 float64) (float64, error) {
+	r.record("DegMidp", x1, x0)
+	return r.swe.DegMidp(x1, x0)
+}
+
+func (r *Recorder) RadMidp(x1, x0 float64) (float64, error) {
+	r.record("RadMidp", x1, x0)
+	return r.swe.RadMidp(x1, x0)
+}
+
+func (r *Recorder) SplitDeg(x float64, fl SplitDegFlags) (deg, min, sec int, secfr float64, sign int, err error) {
+	r.record("SplitDeg", x, fl)
+	return r.swe.SplitDeg(x, fl)
+}
@@ -0,0 +1,22 @@
+package swego
+
+// Obliquity calls Calc for the special body EclNut at et and returns the true
+// and mean obliquity of the ecliptic and the nutation in longitude and in
+// obliquity, all in degrees. The true obliquity is the value of eps expected
+// by functions like Cotrans and HousePos.
+func Obliquity(swe Interface, et float64, fl *CalcFlags) (trueEps, meanEps, nutLong, nutObl float64, err error) {
+	xx, _, err := swe.Calc(et, EclNut, fl)
+	if len(xx) < 4 {
+		return 0, 0, 0, 0, err
+	}
+
+	return xx[0], xx[1], xx[2], xx[3], err
+}
+
+// Nutation calls Calc for the special body EclNut at et and returns the
+// nutation in longitude dpsi and in obliquity deps, both in degrees. Multiply
+// by 3600 for the values in arc seconds as given in most tables.
+func Nutation(swe Interface, et float64, fl *CalcFlags) (dpsi, deps float64, err error) {
+	_, _, dpsi, deps, err = Obliquity(swe, et, fl)
+	return
+}
@@ -0,0 +1,905 @@
+package swego
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrPoolClosed is returned by the functions of a Pool after Close is called.
+var ErrPoolClosed = errors.New("swisseph: pool closed")
+
+// A Pool dispatches calls to a fixed set of worker goroutines, each owning its
+// own library handle, so that up to size calls are executed in parallel. Each
+// call is executed by a free worker, if all workers are busy the call blocks
+// until one becomes free.
+//
+// The Swiss Ephemeris C library keeps global state, real parallelism requires
+// independent library states, for example handles that each talk to a
+// separate worker process. Handles sharing the same library state gain no
+// parallelism over a single handle.
+//
+// SetPath changes the ephemeris path of all handles. Other library state that
+// is not part of the Interface is changed via Broadcast to keep all handles
+// consistent. The topocentric location and the sidereal mode are passed with
+// the flags of each call and need no broadcast.
+//
+// A Pool implements ExclusiveLocker, Locked reserves a single worker and
+// passes its handle to the callback.
+type Pool struct {
+	mu      sync.RWMutex // guards closed, held for reading while submitting
+	bmu     sync.Mutex   // serializes Broadcast
+	jobs    chan poolJob
+	handles []Interface
+	wg      sync.WaitGroup
+
+	closed bool
+}
+
+// A poolJob is executed by a worker, the value recovered from a panic of fn is
+// sent on done.
+type poolJob struct {
+	fn   func(sw Interface)
+	done chan interface{}
+}
+
+// NewPool returns a pool of size workers, each owning a handle created by
+// calling factory. It panics if size is smaller than 1 or factory is nil.
+func NewPool(size int, factory func() Interface) *Pool {
+	if size < 1 {
+		panic("size must be at least 1")
+	}
+
+	if factory == nil {
+		panic("factory is nil")
+	}
+
+	p := &Pool{
+		jobs:    make(chan poolJob),
+		handles: make([]Interface, size),
+	}
+
+	p.wg.Add(size)
+	for i := range p.handles {
+		p.handles[i] = factory()
+		go p.work(p.handles[i])
+	}
+
+	return p
+}
+
+// work executes the jobs submitted to the pool with handle sw until the pool
+// is closed.
+func (p *Pool) work(sw Interface) {
+	defer p.wg.Done()
+
+	for job := range p.jobs {
+		job.done <- runJob(sw, job.fn)
+	}
+}
+
+func runJob(sw Interface, fn func(sw Interface)) (r interface{}) {
+	defer func() { r = recover() }()
+	fn(sw)
+	return nil
+}
+
+// submit passes fn to a free worker and returns the channel on which the
+// worker reports completion.
+func (p *Pool) submit(fn func(sw Interface)) (<-chan interface{}, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+
+	done := make(chan interface{}, 1)
+	p.jobs <- poolJob{fn, done}
+	return done, nil
+}
+
+// do executes fn by a free worker and returns the error returned by fn. A
+// panic of fn is repeated in the calling goroutine.
+func (p *Pool) do(fn func(sw Interface) error) error {
+	var err error
+	done, serr := p.submit(func(sw Interface) { err = fn(sw) })
+	if serr != nil {
+		return serr
+	}
+
+	if r := <-done; r != nil {
+		panic(r)
+	}
+
+	return err
+}
+
+// reserve makes a free worker wait until release is closed and returns its
+// handle, so that the handle is used by the caller only.
+func (p *Pool) reserve(release <-chan struct{}) (Interface, error) {
+	handle := make(chan Interface)
+	_, err := p.submit(func(sw Interface) {
+		handle <- sw
+		<-release
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return <-handle, nil
+}
+
+// ExclusiveLock reserves a free worker and returns its handle, exclusively
+// locked if the handle implements ExclusiveLocker. ExclusiveUnlock returns
+// the worker to the pool.
+func (p *Pool) ExclusiveLock() LockedInterface {
+	release := make(chan struct{})
+	sw, err := p.reserve(release)
+	if err != nil {
+		return nopLocked{p} // all calls return ErrPoolClosed
+	}
+
+	var li LockedInterface = nopLocked{sw}
+	if l, ok := sw.(ExclusiveLocker); ok {
+		li = l.ExclusiveLock()
+	}
+
+	return poolLocked{li, release}
+}
+
+type poolLocked struct {
+	LockedInterface
+	release chan struct{}
+}
+
+func (l poolLocked) ExclusiveUnlock() {
+	l.LockedInterface.ExclusiveUnlock()
+	close(l.release)
+}
+
+// Broadcast calls fn with the handle of every worker while no other calls are
+// executed. Use it to change library state that is not passed via the Interface
+// functions. All handles are passed to fn, the first error returned by fn is
+// returned.
+func (p *Pool) Broadcast(fn func(swe Interface) error) error {
+	p.bmu.Lock()
+	defer p.bmu.Unlock()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	// a reserved worker takes no other job, so each handle is reserved once
+	all := make([]Interface, len(p.handles))
+	for i := range all {
+		sw, err := p.reserve(release)
+		if err != nil {
+			return err
+		}
+
+		all[i] = sw
+	}
+
+	var first error
+	for _, sw := range all {
+		if err := fn(sw); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+// SetPath sets the ephemeris path of all handles that implement a SetPath
+// method, like the handles of package swecgo.
+func (p *Pool) SetPath(path string) error {
+	return p.Broadcast(func(swe Interface) error {
+		if sp, ok := swe.(interface{ SetPath(path string) }); ok {
+			sp.SetPath(path)
+		}
+
+		return nil
+	})
+}
+
+// Close waits for all running calls to finish, stops the workers and closes
+// the handles that implement a Close method. Subsequent calls return
+// ErrPoolClosed.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+
+	p.closed = true
+	close(p.jobs)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	for _, sw := range p.handles {
+		if c, ok := sw.(interface{ Close() }); ok {
+			c.Close()
+		}
+	}
+}
+
+func (p *Pool) Version() (string, error) {
+	var res string
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.Version()
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) PlanetName(pl Planet) (string, error) {
+	var res string
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.PlanetName(pl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) Calc(et float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		xx, cfl, err = sw.Calc(et, pl, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) CalcUT(ut float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		xx, cfl, err = sw.CalcUT(ut, pl, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) CalcPctr(et float64, pl, plctr Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		xx, cfl, err = sw.CalcPctr(et, pl, plctr, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) FixStar(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		name, xx, cfl, err = sw.FixStar(star, et, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) FixStarUT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		name, xx, cfl, err = sw.FixStarUT(star, ut, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) FixStar2(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		name, xx, cfl, err = sw.FixStar2(star, et, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) FixStar2UT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		name, xx, cfl, err = sw.FixStar2UT(star, ut, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) FixStarMag(star string) (name string, mag float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		name, mag, err = sw.FixStarMag(star)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) NodAps(et float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		nasc, ndsc, peri, aphe, err = sw.NodAps(et, pl, fl, m)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) NodApsUT(ut float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		nasc, ndsc, peri, aphe, err = sw.NodApsUT(ut, pl, fl, m)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) Pheno(et float64, pl Planet, fl *CalcFlags) (attr []float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		attr, err = sw.Pheno(et, pl, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) PhenoUT(ut float64, pl Planet, fl *CalcFlags) (attr []float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		attr, err = sw.PhenoUT(ut, pl, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) OrbitalElements(et float64, pl Planet, fl *CalcFlags) ([]float64, error) {
+	var res []float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.OrbitalElements(et, pl, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) OrbitMaxMinTrueDistance(et float64, pl Planet, fl *CalcFlags) (dmax, dmin, dtrue float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		dmax, dmin, dtrue, err = sw.OrbitMaxMinTrueDistance(et, pl, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) SolCross(x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.SolCross(x2cross, et, fl, backward)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) SolCrossUT(x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.SolCrossUT(x2cross, ut, fl, backward)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) MoonCross(x2cross, et float64, fl *CalcFlags) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.MoonCross(x2cross, et, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) MoonCrossUT(x2cross, ut float64, fl *CalcFlags) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.MoonCrossUT(x2cross, ut, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) MoonCrossNode(et float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		jd, xlon, xlat, err = sw.MoonCrossNode(et, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) MoonCrossNodeUT(ut float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		jd, xlon, xlat, err = sw.MoonCrossNodeUT(ut, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) HelioCross(pl Planet, x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.HelioCross(pl, x2cross, et, fl, backward)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) HelioCrossUT(pl Planet, x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.HelioCrossUT(pl, x2cross, ut, fl, backward)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) GetAyanamsaEx(et float64, fl *AyanamsaExFlags) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.GetAyanamsaEx(et, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) GetAyanamsaExUT(ut float64, fl *AyanamsaExFlags) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.GetAyanamsaExUT(ut, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) GetAyanamsaName(ayan Ayanamsa) (string, error) {
+	var res string
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.GetAyanamsaName(ayan)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) JulDay(y, m, d int, h float64, ct CalType) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.JulDay(y, m, d, h, ct)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) DateConversion(y, m, d int, h float64, ct CalType) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.DateConversion(y, m, d, h, ct)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) RevJul(jd float64, ct CalType) (y, m, d int, h float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		y, m, d, h, err = sw.RevJul(jd, ct)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) UTCToJD(y, m, d, h, i int, s float64, fl *DateConvertFlags) (et, ut float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		et, ut, err = sw.UTCToJD(y, m, d, h, i, s, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) JdETToUTC(et float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		y, m, d, h, i, s, err = sw.JdETToUTC(et, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) JdUT1ToUTC(ut1 float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		y, m, d, h, i, s, err = sw.JdUT1ToUTC(ut1, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) DayOfWeek(jd float64) (int, error) {
+	var res int
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.DayOfWeek(jd)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) HousesEx(ut float64, fl *HousesExFlags, geo *GeoLoc, hsys HSys) ([]float64, []float64, error) {
+	var cusps, ascmc []float64
+	err := p.do(func(sw Interface) (err error) {
+		cusps, ascmc, err = sw.HousesEx(ut, fl, geo, hsys)
+		return
+	})
+
+	return cusps, ascmc, err
+}
+
+func (p *Pool) HousesARMC(armc, geolat, eps float64, hsys HSys) ([]float64, []float64, error) {
+	var cusps, ascmc []float64
+	err := p.do(func(sw Interface) (err error) {
+		cusps, ascmc, err = sw.HousesARMC(armc, geolat, eps, hsys)
+		return
+	})
+
+	return cusps, ascmc, err
+}
+
+func (p *Pool) HousePos(armc, geolat, eps float64, hsys HSys, pllng, pllat float64) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.HousePos(armc, geolat, eps, hsys, pllng, pllat)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) HouseName(hsys HSys) (string, error) {
+	var res string
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.HouseName(hsys)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) GauquelinSector(ut float64, pl Planet, star string, fl *CalcFlags, m GauquelinMethod, geo *GeoLoc, press, temp float64) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.GauquelinSector(ut, pl, star, fl, m, geo, press, temp)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) DeltaTEx(jd float64, eph Ephemeris) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.DeltaTEx(jd, eph)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) TimeEqu(jd float64, fl *TimeEquFlags) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.TimeEqu(jd, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) LMTToLAT(jdLMT, geolon float64, fl *TimeEquFlags) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.LMTToLAT(jdLMT, geolon, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) LATToLMT(jdLAT, geolon float64, fl *TimeEquFlags) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.LATToLMT(jdLAT, geolon, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) SidTime0(ut, eps, nut float64, fl *SidTimeFlags) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.SidTime0(ut, eps, nut, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) SidTime(ut float64, fl *SidTimeFlags) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.SidTime(ut, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) Azalt(ut float64, m AzaltMode, geo *GeoLoc, press, temp float64, in [3]float64, fl *AzaltFlags) ([]float64, error) {
+	var res []float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.Azalt(ut, m, geo, press, temp, in, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) AzaltRev(ut float64, m AzaltRevMode, geo *GeoLoc, in [2]float64, fl *AzaltFlags) ([]float64, error) {
+	var res []float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.AzaltRev(ut, m, geo, in, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) Refrac(alt, press, temp float64, m RefracMode) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.Refrac(alt, press, temp, m)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) RefracExtended(alt, geoalt, press, temp, lapseRate float64, m RefracMode) (float64, []float64, error) {
+	var refr float64
+	var dret []float64
+	err := p.do(func(sw Interface) (err error) {
+		refr, dret, err = sw.RefracExtended(alt, geoalt, press, temp, lapseRate, m)
+		return
+	})
+
+	return refr, dret, err
+}
+
+func (p *Pool) RiseTrans(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp float64) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.RiseTrans(ut, pl, star, fl, m, geo, press, temp)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) RiseTransTrueHor(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp, horhgt float64) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.RiseTransTrueHor(ut, pl, star, fl, m, geo, press, temp, horhgt)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) HeliacalUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	var res []float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.HeliacalUT(ut, geo, atm, obs, obj, ev, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) HeliacalPhenoUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	var res []float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.HeliacalPhenoUT(ut, geo, atm, obs, obj, ev, fl)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) VisLimitMag(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, fl *HeliacalFlags) (dret []float64, visible bool, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		dret, visible, err = sw.VisLimitMag(ut, geo, atm, obs, obj, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) SolEclipseWhere(ut float64, fl *CalcFlags) (geo, attr []float64, typ EclType, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		geo, attr, typ, err = sw.SolEclipseWhere(ut, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) SolEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		attr, typ, err = sw.SolEclipseHow(ut, fl, geo)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) SolEclipseWhenGlob(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		tret, rtyp, err = sw.SolEclipseWhenGlob(ut, fl, typ, backward)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) SolEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		tret, attr, typ, err = sw.SolEclipseWhenLoc(ut, fl, geo, backward)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) LunEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		attr, typ, err = sw.LunEclipseHow(ut, fl, geo)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) LunEclipseWhen(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		tret, rtyp, err = sw.LunEclipseWhen(ut, fl, typ, backward)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) LunEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		tret, attr, typ, err = sw.LunEclipseWhenLoc(ut, fl, geo, backward)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) LunOccultWhere(ut float64, pl Planet, star string, fl *CalcFlags) (geo, attr []float64, typ EclType, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		geo, attr, typ, err = sw.LunOccultWhere(ut, pl, star, fl)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) LunOccultWhenGlob(ut float64, pl Planet, star string, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		tret, rtyp, err = sw.LunOccultWhenGlob(ut, pl, star, fl, typ, backward)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) LunOccultWhenLoc(ut float64, pl Planet, star string, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		tret, attr, typ, err = sw.LunOccultWhenLoc(ut, pl, star, fl, geo, backward)
+		return
+	})
+
+	return
+}
+
+func (p *Pool) Cotrans(in [3]float64, eps float64) ([]float64, error) {
+	var res []float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.Cotrans(in, eps)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) CotransSp(in [6]float64, eps float64) ([]float64, error) {
+	var res []float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.CotransSp(in, eps)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) DegNorm(x float64) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.DegNorm(x)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) RadNorm(x float64) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.RadNorm(x)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) DegMidp(x1, x0 float64) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.DegMidp(x1, x0)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) RadMidp(x1, x0 float64) (float64, error) {
+	var res float64
+	err := p.do(func(sw Interface) (err error) {
+		res, err = sw.RadMidp(x1, x0)
+		return
+	})
+
+	return res, err
+}
+
+func (p *Pool) SplitDeg(x float64, fl SplitDegFlags) (deg, min, sec int, secfr float64, sign int, err error) {
+	err = p.do(func(sw Interface) (err error) {
+		deg, min, sec, secfr, sign, err = sw.SplitDeg(x, fl)
+		return
+	})
+
+	return
+}
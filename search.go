@@ -0,0 +1,216 @@
+package swego
+
+import "math"
+
+// ErrNoStation is returned by NextStation for bodies that are never
+// retrograde and if no station is found within the search period.
+var ErrNoStation = Error{Code: -1, Message: "no station found"}
+
+// ErrNoAspect is returned by NextAspect, NextAntisciaContact,
+// NextDeclinationParallel and VoidOfCourseMoon if the aspect is not formed
+// within the search period, for example for two bodies moving in parallel.
+var ErrNoAspect = Error{Code: -1, Message: "no aspect found"}
+
+// Parameters of the searches for stations and aspects.
+const (
+	searchStep      = 1.0  // step in days to bracket a root
+	searchMaxDays   = 1000 // maximum searched period in days
+	searchPrecision = 1e-7 // precision of a root in days, about 0.01 seconds
+)
+
+// NextStation returns the Julian Date (in Universal Time) of the first
+// station of body pl after jdStart (in Universal Time), at which the speed in
+// longitude of the body crosses zero. The search proceeds forward in time
+// for up to 1000 days. It returns true for turningRetro if the body turns
+// from direct to retrograde motion. The speed is computed by CalcUT with
+// FlagSpeed set in a copy of fl. It returns ErrNoStation for the Sun and the
+// Moon, which are never retrograde, and if no station is found.
+func NextStation(swe Interface, jdStart float64, pl Planet, fl *CalcFlags) (jdStation float64, turningRetro bool, err error) {
+	if pl == Sun || pl == Moon {
+		return 0, false, ErrNoStation
+	}
+
+	fl = withFlags(fl, FlagSpeed)
+	speed := func(ut float64) (float64, error) {
+		xx, _, err := swe.CalcUT(ut, pl, fl)
+		if err != nil && !IsWarning(err) {
+			return 0, err
+		}
+
+		if len(xx) < 4 {
+			return 0, ErrShortResult
+		}
+
+		return xx[3], nil
+	}
+
+	jd, before, found, err := findRoot(speed, jdStart)
+	if err == nil && !found {
+		err = ErrNoStation
+	}
+
+	return jd, before > 0, err
+}
+
+// findRoot returns the first time after start at which fn changes sign
+// within the maximum searched period and the value of fn before the sign
+// change. A change between values more than 180 apart is taken as a jump of
+// an angle from -180 to 180 and not as a root. It returns false for found if
+// fn does not change sign.
+func findRoot(fn func(jd float64) (float64, error), start float64) (jd, before float64, found bool, err error) {
+	t0 := start
+	v0, err := fn(t0)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	for t1 := t0 + searchStep; t1 <= start+searchMaxDays; t1 += searchStep {
+		v1, err := fn(t1)
+		if err != nil {
+			return 0, 0, false, err
+		}
+
+		if math.Signbit(v0) != math.Signbit(v1) && math.Abs(v1-v0) < 180 {
+			jd, err := bisect(fn, t0, t1, v0)
+			return jd, v0, err == nil, err
+		}
+
+		t0, v0 = t1, v1
+	}
+
+	return 0, 0, false, nil
+}
+
+// bisect returns the root of fn between t0 and t1, fn has value v0 at t0 and
+// a different sign at t1.
+func bisect(fn func(jd float64) (float64, error), t0, t1, v0 float64) (float64, error) {
+	for t1-t0 > searchPrecision {
+		tm := (t0 + t1) / 2
+		vm, err := fn(tm)
+		if err != nil {
+			return 0, err
+		}
+
+		if math.Signbit(vm) == math.Signbit(v0) {
+			t0, v0 = tm, vm
+		} else {
+			t1 = tm
+		}
+	}
+
+	return (t0 + t1) / 2, nil
+}
+
+// NextAspect returns the Julian Date (in Universal Time) of the first exact
+// aspect between bodies p1 and p2 after jdStart (in Universal Time), at which
+// the longitude of p1 minus the longitude of p2 equals aspectDeg modulo 360.
+// The aspect is directional, use 360 - aspectDeg for the aspect with p1
+// behind p2, conjunction and opposition are the same both ways. The longitudes
+// are computed by CalcUT with calculation flags fl. The search proceeds
+// forward in time in steps of one day for up to 1000 days, which is short
+// enough to bracket each aspect of the Moon, moving about 13° a day. It
+// returns ErrNoAspect if no aspect is found, for example if the bodies move
+// in parallel or if the search period is too short for two slow bodies.
+func NextAspect(swe Interface, jdStart float64, p1, p2 Planet, aspectDeg float64, fl *CalcFlags) (float64, error) {
+	return nextContact(swe, jdStart, p1, p2, fl, 0, func(lon1, lon2 float64) float64 {
+		return lon1 - lon2 - aspectDeg
+	})
+}
+
+// Antiscion returns the antiscion of ecliptic longitude lon in degrees, the
+// reflection of lon across the axis of 0° Cancer and 0° Capricorn.
+func Antiscion(lon float64) float64 { return degNorm(180 - lon) }
+
+// ContraAntiscion returns the contra-antiscion of ecliptic longitude lon in
+// degrees, the reflection of lon across the axis of 0° Aries and 0° Libra.
+// It is opposite to the antiscion.
+func ContraAntiscion(lon float64) float64 { return degNorm(-lon) }
+
+// NextAntisciaContact returns the Julian Date (in Universal Time) of the
+// first time after jdStart (in Universal Time) at which the antiscion of
+// body p1 is at the longitude of body p2, which is also when the antiscion of
+// p2 is at the longitude of p1. The search is equal to that of NextAspect and
+// returns ErrNoAspect if no contact is found.
+func NextAntisciaContact(swe Interface, jdStart float64, p1, p2 Planet, fl *CalcFlags) (float64, error) {
+	return nextContact(swe, jdStart, p1, p2, fl, 0, func(lon1, lon2 float64) float64 {
+		return Antiscion(lon1) - lon2
+	})
+}
+
+// Declination calls CalcEquatorial and returns the declination of body pl at
+// et in degrees.
+func Declination(swe Interface, et float64, pl Planet, fl *CalcFlags) (float64, error) {
+	_, dec, _, _, _, _, err := CalcEquatorial(swe, et, pl, fl)
+	return dec, err
+}
+
+// OutOfBounds returns the declination decl of body pl at et and the true
+// obliquity of the ecliptic, both in degrees. The body is out of bounds, oob
+// is true, if its declination north or south exceeds the obliquity, which the
+// Sun never does. The Moon is out of bounds for some days each month in the
+// years around a major lunar standstill, like in 2006 and 2025.
+func OutOfBounds(swe Interface, et float64, pl Planet, fl *CalcFlags) (decl, obliquity float64, oob bool, err error) {
+	decl, err = Declination(swe, et, pl, fl)
+	if err != nil && !IsWarning(err) {
+		return 0, 0, false, err
+	}
+
+	obliquity, _, _, _, err = Obliquity(swe, et, fl)
+	if err != nil && !IsWarning(err) {
+		return 0, 0, false, err
+	}
+
+	return decl, obliquity, math.Abs(decl) > obliquity, nil
+}
+
+// NextDeclinationParallel returns the Julian Date (in Universal Time) of the
+// first exact parallel of declination of bodies p1 and p2 after jdStart (in
+// Universal Time), at which both bodies have the same declination, or the
+// first contraparallel if contra is true, at which the declinations are
+// equal but of opposite sign. The declinations are computed by CalcUT with
+// FlagEquatorial set in a copy of fl. The search is equal to that of
+// NextAspect and returns ErrNoAspect if no parallel is found.
+func NextDeclinationParallel(swe Interface, jdStart float64, p1, p2 Planet, contra bool, fl *CalcFlags) (float64, error) {
+	sign := -1.0
+	if contra {
+		sign = 1
+	}
+
+	return nextContact(swe, jdStart, p1, p2, withFlags(fl, FlagEquatorial), 1,
+		func(dec1, dec2 float64) float64 { return dec1 + sign*dec2 })
+}
+
+// nextContact returns the first time after jdStart at which angle fn of the
+// coordinates at index i of the positions of bodies p1 and p2 is 0 modulo
+// 360.
+func nextContact(swe Interface, jdStart float64, p1, p2 Planet, fl *CalcFlags, i int, fn func(x1, x2 float64) float64) (float64, error) {
+	dist := func(ut float64) (float64, error) {
+		xx1, _, err := swe.CalcUT(ut, p1, fl)
+		if err != nil && !IsWarning(err) {
+			return 0, err
+		}
+
+		xx2, _, err := swe.CalcUT(ut, p2, fl)
+		if err != nil && !IsWarning(err) {
+			return 0, err
+		}
+
+		if len(xx1) <= i || len(xx2) <= i {
+			return 0, ErrShortResult
+		}
+
+		return angleDiff(fn(xx1[i], xx2[i])), nil
+	}
+
+	jd, _, found, err := findRoot(dist, jdStart)
+	if err == nil && !found {
+		err = ErrNoAspect
+	}
+
+	return jd, err
+}
+
+// angleDiff returns angle x in degrees normalized to -180 <= x < 180.
+func angleDiff(x float64) float64 {
+	return degNorm(x+180) - 180
+}
@@ -0,0 +1,481 @@
+package swego
+
+import "sync"
+
+// Serialized returns a library handle that guards every function of swe with a
+// single mutex, so that a pool of goroutines can safely share an
+// implementation that is not safe for concurrent use itself. The underlying C
+// library keeps global state, such as the ephemeris path, the topocentric
+// location and the sidereal mode, which makes concurrent calls unsafe.
+//
+// All access is serialized, calls do not run in parallel. For true
+// parallelism run multiple processes, each owning its own library state, and
+// shard the work between them.
+//
+// The returned handle implements ExclusiveLocker, so it can be used with
+// Locked to perform a sequence of calls without interference of other
+// goroutines. If swe is nil, it panics.
+func Serialized(swe Interface) Interface {
+	if swe == nil {
+		panic("swe is nil")
+	}
+
+	return &serialized{swe: swe}
+}
+
+type serialized struct {
+	mu  sync.Mutex
+	swe Interface
+}
+
+func (w *serialized) ExclusiveLock() LockedInterface {
+	w.mu.Lock()
+
+	li := &serializedLocked{Interface: w.swe, mu: &w.mu}
+	if l, ok := w.swe.(ExclusiveLocker); ok {
+		li.inner = l.ExclusiveLock()
+		li.Interface = li.inner
+	}
+
+	return li
+}
+
+type serializedLocked struct {
+	Interface
+	inner LockedInterface
+	mu    *sync.Mutex
+}
+
+func (l *serializedLocked) ExclusiveUnlock() {
+	if l.inner != nil {
+		l.inner.ExclusiveUnlock()
+	}
+
+	l.mu.Unlock()
+}
+
+func (w *serialized) Version() (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.Version()
+}
+
+func (w *serialized) PlanetName(pl Planet) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.PlanetName(pl)
+}
+
+func (w *serialized) Calc(et float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.Calc(et, pl, fl)
+}
+
+func (w *serialized) CalcUT(ut float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.CalcUT(ut, pl, fl)
+}
+
+func (w *serialized) CalcPctr(et float64, pl, plctr Planet, fl *CalcFlags) (xx []float64, cfl int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.CalcPctr(et, pl, plctr, fl)
+}
+
+func (w *serialized) FixStar(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.FixStar(star, et, fl)
+}
+
+func (w *serialized) FixStarUT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.FixStarUT(star, ut, fl)
+}
+
+func (w *serialized) FixStar2(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.FixStar2(star, et, fl)
+}
+
+func (w *serialized) FixStar2UT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.FixStar2UT(star, ut, fl)
+}
+
+func (w *serialized) FixStarMag(star string) (name string, mag float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.FixStarMag(star)
+}
+
+func (w *serialized) NodAps(et float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.NodAps(et, pl, fl, m)
+}
+
+func (w *serialized) NodApsUT(ut float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.NodApsUT(ut, pl, fl, m)
+}
+
+func (w *serialized) Pheno(et float64, pl Planet, fl *CalcFlags) (attr []float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.Pheno(et, pl, fl)
+}
+
+func (w *serialized) PhenoUT(ut float64, pl Planet, fl *CalcFlags) (attr []float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.PhenoUT(ut, pl, fl)
+}
+
+func (w *serialized) OrbitalElements(et float64, pl Planet, fl *CalcFlags) ([]float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.OrbitalElements(et, pl, fl)
+}
+
+func (w *serialized) OrbitMaxMinTrueDistance(et float64, pl Planet, fl *CalcFlags) (dmax, dmin, dtrue float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.OrbitMaxMinTrueDistance(et, pl, fl)
+}
+
+func (w *serialized) SolCross(x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.SolCross(x2cross, et, fl, backward)
+}
+
+func (w *serialized) SolCrossUT(x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.SolCrossUT(x2cross, ut, fl, backward)
+}
+
+func (w *serialized) MoonCross(x2cross, et float64, fl *CalcFlags) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.MoonCross(x2cross, et, fl)
+}
+
+func (w *serialized) MoonCrossUT(x2cross, ut float64, fl *CalcFlags) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.MoonCrossUT(x2cross, ut, fl)
+}
+
+func (w *serialized) MoonCrossNode(et float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.MoonCrossNode(et, fl)
+}
+
+func (w *serialized) MoonCrossNodeUT(ut float64, fl *CalcFlags) (jd, xlon, xlat float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.MoonCrossNodeUT(ut, fl)
+}
+
+func (w *serialized) HelioCross(pl Planet, x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.HelioCross(pl, x2cross, et, fl, backward)
+}
+
+func (w *serialized) HelioCrossUT(pl Planet, x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.HelioCrossUT(pl, x2cross, ut, fl, backward)
+}
+
+func (w *serialized) GetAyanamsaEx(et float64, fl *AyanamsaExFlags) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.GetAyanamsaEx(et, fl)
+}
+
+func (w *serialized) GetAyanamsaExUT(ut float64, fl *AyanamsaExFlags) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.GetAyanamsaExUT(ut, fl)
+}
+
+func (w *serialized) GetAyanamsaName(ayan Ayanamsa) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.GetAyanamsaName(ayan)
+}
+
+func (w *serialized) JulDay(y, m, d int, h float64, ct CalType) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.JulDay(y, m, d, h, ct)
+}
+
+func (w *serialized) DateConversion(y, m, d int, h float64, ct CalType) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.DateConversion(y, m, d, h, ct)
+}
+
+func (w *serialized) RevJul(jd float64, ct CalType) (y, m, d int, h float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.RevJul(jd, ct)
+}
+
+func (w *serialized) UTCToJD(y, m, d, h, i int, s float64, fl *DateConvertFlags) (et, ut float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.UTCToJD(y, m, d, h, i, s, fl)
+}
+
+func (w *serialized) JdETToUTC(et float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.JdETToUTC(et, fl)
+}
+
+func (w *serialized) JdUT1ToUTC(ut1 float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.JdUT1ToUTC(ut1, fl)
+}
+
+func (w *serialized) DayOfWeek(jd float64) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.DayOfWeek(jd)
+}
+
+func (w *serialized) HousesEx(ut float64, fl *HousesExFlags, geo *GeoLoc, hsys HSys) ([]float64, []float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.HousesEx(ut, fl, geo, hsys)
+}
+
+func (w *serialized) HousesARMC(armc, geolat, eps float64, hsys HSys) ([]float64, []float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.HousesARMC(armc, geolat, eps, hsys)
+}
+
+func (w *serialized) HousePos(armc, geolat, eps float64, hsys HSys, pllng, pllat float64) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.HousePos(armc, geolat, eps, hsys, pllng, pllat)
+}
+
+func (w *serialized) HouseName(hsys HSys) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.HouseName(hsys)
+}
+
+func (w *serialized) GauquelinSector(ut float64, pl Planet, star string, fl *CalcFlags, m GauquelinMethod, geo *GeoLoc, press, temp float64) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.GauquelinSector(ut, pl, star, fl, m, geo, press, temp)
+}
+
+func (w *serialized) DeltaTEx(jd float64, eph Ephemeris) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.DeltaTEx(jd, eph)
+}
+
+func (w *serialized) TimeEqu(jd float64, fl *TimeEquFlags) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.TimeEqu(jd, fl)
+}
+
+func (w *serialized) LMTToLAT(jdLMT, geolon float64, fl *TimeEquFlags) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.LMTToLAT(jdLMT, geolon, fl)
+}
+
+func (w *serialized) LATToLMT(jdLAT, geolon float64, fl *TimeEquFlags) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.LATToLMT(jdLAT, geolon, fl)
+}
+
+func (w *serialized) SidTime0(ut, eps, nut float64, fl *SidTimeFlags) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.SidTime0(ut, eps, nut, fl)
+}
+
+func (w *serialized) SidTime(ut float64, fl *SidTimeFlags) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.SidTime(ut, fl)
+}
+
+func (w *serialized) Azalt(ut float64, m AzaltMode, geo *GeoLoc, press, temp float64, in [3]float64, fl *AzaltFlags) ([]float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.Azalt(ut, m, geo, press, temp, in, fl)
+}
+
+func (w *serialized) AzaltRev(ut float64, m AzaltRevMode, geo *GeoLoc, in [2]float64, fl *AzaltFlags) ([]float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.AzaltRev(ut, m, geo, in, fl)
+}
+
+func (w *serialized) Refrac(alt, press, temp float64, m RefracMode) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.Refrac(alt, press, temp, m)
+}
+
+func (w *serialized) RefracExtended(alt, geoalt, press, temp, lapseRate float64, m RefracMode) (float64, []float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.RefracExtended(alt, geoalt, press, temp, lapseRate, m)
+}
+
+func (w *serialized) RiseTrans(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp float64) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.RiseTrans(ut, pl, star, fl, m, geo, press, temp)
+}
+
+func (w *serialized) RiseTransTrueHor(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp, horhgt float64) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.RiseTransTrueHor(ut, pl, star, fl, m, geo, press, temp, horhgt)
+}
+
+func (w *serialized) HeliacalUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.HeliacalUT(ut, geo, atm, obs, obj, ev, fl)
+}
+
+func (w *serialized) HeliacalPhenoUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.HeliacalPhenoUT(ut, geo, atm, obs, obj, ev, fl)
+}
+
+func (w *serialized) VisLimitMag(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, fl *HeliacalFlags) (dret []float64, visible bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.VisLimitMag(ut, geo, atm, obs, obj, fl)
+}
+
+func (w *serialized) SolEclipseWhere(ut float64, fl *CalcFlags) (geo, attr []float64, typ EclType, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.SolEclipseWhere(ut, fl)
+}
+
+func (w *serialized) SolEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.SolEclipseHow(ut, fl, geo)
+}
+
+func (w *serialized) SolEclipseWhenGlob(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.SolEclipseWhenGlob(ut, fl, typ, backward)
+}
+
+func (w *serialized) SolEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.SolEclipseWhenLoc(ut, fl, geo, backward)
+}
+
+func (w *serialized) LunEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.LunEclipseHow(ut, fl, geo)
+}
+
+func (w *serialized) LunEclipseWhen(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.LunEclipseWhen(ut, fl, typ, backward)
+}
+
+func (w *serialized) LunEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.LunEclipseWhenLoc(ut, fl, geo, backward)
+}
+
+func (w *serialized) LunOccultWhere(ut float64, pl Planet, star string, fl *CalcFlags) (geo, attr []float64, typ EclType, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.LunOccultWhere(ut, pl, star, fl)
+}
+
+func (w *serialized) LunOccultWhenGlob(ut float64, pl Planet, star string, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.LunOccultWhenGlob(ut, pl, star, fl, typ, backward)
+}
+
+func (w *serialized) LunOccultWhenLoc(ut float64, pl Planet, star string, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.LunOccultWhenLoc(ut, pl, star, fl, geo, backward)
+}
+
+func (w *serialized) Cotrans(in [3]float64, eps float64) ([]float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.Cotrans(in, eps)
+}
+
+func (w *serialized) CotransSp(in [6]float64, eps float64) ([]float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.CotransSp(in, eps)
+}
+
+func (w *serialized) DegNorm(x float64) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.DegNorm(x)
+}
+
+func (w *serialized) RadNorm(x float64) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.RadNorm(x)
+}
+
+func (w *serialized) DegMidp(x1, x0 float64) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.DegMidp(x1, x0)
+}
+
+func (w *serialized) RadMidp(x1, x0 float64) (float64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.RadMidp(x1, x0)
+}
+
+func (w *serialized) SplitDeg(x float64, fl SplitDegFlags) (deg, min, sec int, secfr float64, sign int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.swe.SplitDeg(x, fl)
+}
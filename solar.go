@@ -0,0 +1,68 @@
+package swego
+
+import "math"
+
+// SolarPhaseOrbs are the limits of the elongation of a planet from the Sun in
+// degrees used by SolarPhaseCondition. A nil *SolarPhaseOrbs selects the
+// traditional limits of DefaultSolarPhaseOrbs.
+type SolarPhaseOrbs struct {
+	Cazimi     float64 // in the heart of the Sun, traditionally 17'
+	Combust    float64 // traditionally 8°30'
+	UnderBeams float64 // under the Sun's beams, traditionally 15°
+}
+
+// DefaultSolarPhaseOrbs are the traditional limits of the solar phase
+// conditions.
+var DefaultSolarPhaseOrbs = SolarPhaseOrbs{
+	Cazimi:     17. / 60,
+	Combust:    8.5,
+	UnderBeams: 15,
+}
+
+// ErrSolarPhaseSun is returned by SolarPhaseCondition for the Sun.
+var ErrSolarPhaseSun = Error{Code: -1, Message: "solar phase condition of the Sun"}
+
+// SolarPhaseCondition returns the elongation of planet pl from the Sun in
+// ecliptic longitude at ut and its traditional condition: "cazimi" if the
+// elongation is within orbs.Cazimi, "combust" if within orbs.Combust, "under
+// the beams" if within orbs.UnderBeams and "free" otherwise. The elongation is
+// in degrees in the range 0 <= elongation <= 180. Both positions are computed
+// by CalcUT with flags fl. It returns ErrSolarPhaseSun if pl is Sun.
+func SolarPhaseCondition(swe Interface, ut float64, pl Planet, fl *CalcFlags, orbs *SolarPhaseOrbs) (elongation float64, condition string, err error) {
+	if pl == Sun {
+		return 0, "", ErrSolarPhaseSun
+	}
+
+	if orbs == nil {
+		orbs = &DefaultSolarPhaseOrbs
+	}
+
+	sun, _, err := swe.CalcUT(ut, Sun, fl)
+	if err != nil && !IsWarning(err) {
+		return 0, "", err
+	}
+
+	xx, _, err := swe.CalcUT(ut, pl, fl)
+	if err != nil && !IsWarning(err) {
+		return 0, "", err
+	}
+
+	if len(sun) < 1 || len(xx) < 1 {
+		return 0, "", ErrShortResult
+	}
+
+	elongation = math.Abs(angleDiff(xx[0] - sun[0]))
+
+	switch {
+	case elongation <= orbs.Cazimi:
+		condition = "cazimi"
+	case elongation <= orbs.Combust:
+		condition = "combust"
+	case elongation <= orbs.UnderBeams:
+		condition = "under the beams"
+	default:
+		condition = "free"
+	}
+
+	return elongation, condition, nil
+}
@@ -4,6 +4,7 @@ package swecgo
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/astrotools/swego"
 )
@@ -50,3 +51,18 @@ func ExampleLocked() {
 		swe.CalcUT(2451544.5, swego.Sun, fl)
 	})
 }
+
+func ExampleLibrary_DayOfWeek() {
+	swe := Open()
+
+	// 2000-01-01 was a Saturday.
+	dow, _ := swe.DayOfWeek(2451544.5)
+
+	// DayOfWeek counts from Monday, time.Weekday from Sunday.
+	wd := time.Weekday((dow + 1) % 7)
+
+	fmt.Println(dow, wd)
+
+	// Output:
+	// 5 Saturday
+}
@@ -20,18 +20,102 @@ type Library interface {
 	//  RevJul
 	//  JdETToUTC
 	//  JdUT1ToUTC
+	//  DayOfWeek
 	//  HouseName
 	//  SidTime
 	//  SidTime0
+	//  Azalt
+	//  AzaltRev
+	//  Refrac
+	//  RefracExtended
+	//  Cotrans
+	//  CotransSp
+	//  DegNorm
+	//  RadNorm
+	//  DegMidp
+	//  RadMidp
+	//  SplitDeg
 	swego.Interface
 
 	// SetPath opens the ephemeris and sets the data path.
 	SetPath(path string)
 
-	// Close closes the Swiss Ephemeris library.
-	// The ephemeris can be reopened by calling SetPath.
+	// SetEphemeris sets the ephemeris path and for the JPL ephemeris the JPL
+	// file, and returns calculation flags that select ephemeris eph and jplFile.
+	// It returns an error without falling back to another ephemeris if the
+	// files of eph can not be read, which is verified with a test calculation
+	// of the Sun at J2000. The Moshier ephemeris requires no files, jplFile is
+	// only used by the JPL ephemeris.
+	SetEphemeris(eph swego.Ephemeris, path, jplFile string) (*swego.CalcFlags, error)
+
+	// CheckEphemerisFiles returns the names of the Swiss Ephemeris files for
+	// the planets, the Moon and the main asteroids covering the period fromJD
+	// to toJD that are not found in the ephemeris path or can't be read, or
+	// nil if all files are usable. Each file is checked with a trial
+	// calculation. Use it as a preflight check instead of discovering missing
+	// files one at a time through calculation errors.
+	CheckEphemerisFiles(fromJD, toJD float64) (missing []string, err error)
+
+	// Close closes the Swiss Ephemeris library and reverts the ΔT set by
+	// SetDeltaTUserDef to the internal ΔT model. The ephemeris can be reopened
+	// by calling SetPath.
 	Close()
 
+	// PlanetNumber returns the body named name, the reverse of PlanetName. The
+	// name is matched case insensitively against the names of the planets,
+	// points and fictitious bodies, asteroids are not searched. The names
+	// returned by PlanetName are cached until Close or SetPath.
+	PlanetNumber(name string) (swego.Planet, bool)
+
+	// SetTidAcc sets the tidal acceleration of the Moon used to compute ΔT.
+	// Pass swego.TidalAutomatic to revert to the value consistent with the
+	// ephemeris in use. The value is global library state: it applies to
+	// all subsequent calls of every goroutine, including those computing ΔT
+	// implicitly such as CalcUT. Use Locked to scope it to a set of calls.
+	SetTidAcc(tacc float64)
+
+	// GetTidAcc returns the tidal acceleration of the Moon currently used to
+	// compute ΔT.
+	GetTidAcc() float64
+
+	// SetDeltaTUserDef sets dt as ΔT for all subsequent calls that compute ΔT
+	// and have no ΔT set in their flags object, such as CalcUT and DeltaTEx.
+	// Pass swego.DeltaTAutomatic to revert to the internal ΔT model. The value
+	// is global library state shared by all goroutines.
+	SetDeltaTUserDef(dt float64)
+
+	// SetLapseRate sets the atmospheric lapse rate in K/m used by Azalt to
+	// compute the dip of the horizon. The default is swego.LapseRate. The value
+	// is global library state shared by all goroutines.
+	SetLapseRate(lrate float64)
+
+	// GetCurrentFileData returns the path, the period of validity and the JPL
+	// ephemeris number of the ephemeris file loaded in file slot ifno, see
+	// FilePlanet, FileMoon, FileMainAst, FileAnyAst and FileFixStar. It
+	// returns ErrNoFileData if no file is loaded in the slot. The data
+	// reflects the file used by the last calculation.
+	GetCurrentFileData(ifno int) (path string, tfstart, tfend float64, denum int, err error)
+
+	// SetAstroModels sets the astronomical models used for ΔT, precession,
+	// nutation, frame bias and sidereal time. Argument samod is either a comma
+	// separated list of model numbers in the order of the slots
+	// swego.ModelDeltaT to swego.ModelSidT, where 0 selects the default
+	// model, or a version string like "SE2.00" that selects the models used
+	// by that version of the Swiss Ephemeris. Ephemeris eph is used to derive
+	// the tidal acceleration for the older versions. The models are global
+	// library state shared by all goroutines and are reset by Close.
+	SetAstroModels(samod string, eph swego.Ephemeris)
+
+	// GetAstroModels returns the astronomical models currently used as a
+	// comma separated list in the format accepted by SetAstroModels.
+	GetAstroModels() string
+
+	// SidTimeModel is equal to SidTime but uses sidereal time model model, one
+	// of the swego.SidT constants, instead of the model selected by
+	// SetAstroModels. Model 0 selects the default model. The models selected
+	// by SetAstroModels are not changed.
+	SidTimeModel(ut float64, model int, fl *swego.SidTimeFlags) float64
+
 	// used for locking and prevent other interface implementations
 	acquire()
 	release()
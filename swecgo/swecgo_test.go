@@ -3,11 +3,15 @@
 package swecgo
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/astrotools/swego"
 )
@@ -25,6 +29,22 @@ func TestVersionHeaderFile(t *testing.T) {
 	}
 }
 
+func TestVersionOf(t *testing.T) {
+	got, err := swego.VersionOf(swe)
+	if err != nil {
+		t.Fatalf("VersionOf() err = %q", err)
+	}
+
+	want := swego.SemVer{Major: VersionMajor, Minor: VersionMinor, Patch: VersionPatch}
+	if got != want {
+		t.Errorf("VersionOf() = %v, want: %v", got, want)
+	}
+
+	if s := got.String(); s != Version {
+		t.Errorf("VersionOf().String() = %q, want: %q", s, Version)
+	}
+}
+
 func TestConstantCheck(t *testing.T) {
 	if swego.FlagSidereal != flgSidereal {
 		t.Errorf("swego.FlagSidereal = %d, flgSidereal = %d", swego.FlagSidereal, flgSidereal)
@@ -77,6 +97,78 @@ func Test_wrapper_Close(t *testing.T) {
 	})
 }
 
+func Test_wrapper_Close_deltaT(t *testing.T) {
+	t.Parallel()
+
+	Locked(swe, func(swe Library) {
+		const userDef = .5
+		swe.SetDeltaTUserDef(userDef)
+		swe.Close()
+		swe.SetPath(DefaultPath)
+
+		got, err := swe.DeltaTEx(2451544.5, swego.Moshier)
+		if err != nil {
+			t.Fatalf("err = %v, want: nil", err)
+		}
+
+		if got == userDef {
+			swe.SetDeltaTUserDef(swego.DeltaTAutomatic)
+			t.Errorf("user defined ΔT kept after Close; ΔT = %f", got)
+		}
+	})
+}
+
+func Test_wrapper_TidAcc(t *testing.T) {
+	t.Parallel()
+	Locked(swe, func(swe Library) {
+		swe.SetTidAcc(swego.TidalDE200)
+		got := swe.GetTidAcc()
+		swe.SetTidAcc(swego.TidalAutomatic)
+
+		if got != swego.TidalDE200 {
+			t.Errorf("GetTidAcc() = %f, want: %f", got, swego.TidalDE200)
+		}
+	})
+}
+
+func Test_wrapper_AstroModels(t *testing.T) {
+	t.Parallel()
+
+	Locked(swe, func(swe Library) {
+		saved := swe.GetAstroModels()
+		swe.SetAstroModels("1,2,3,4,1,1,1,3", swego.Moshier)
+		got := swe.GetAstroModels()
+		swe.SetAstroModels(saved, swego.Moshier)
+
+		if want := "1,2,3,4,1,1,1,3"; got != want {
+			t.Errorf("GetAstroModels() = %q, want: %q", got, want)
+		}
+
+		if got := swe.GetAstroModels(); got != saved {
+			t.Errorf("GetAstroModels() = %q, want: %q", got, saved)
+		}
+	})
+}
+
+func Test_wrapper_GetCurrentFileData(t *testing.T) {
+	t.Parallel()
+
+	Locked(swe, func(swe Library) {
+		swe.Close() // unload all ephemeris files
+		_, _, _, _, err := swe.GetCurrentFileData(FilePlanet)
+		swe.SetPath(DefaultPath)
+
+		if err != ErrNoFileData {
+			t.Errorf("err = %q, want: %q", err, ErrNoFileData)
+		}
+	})
+
+	_, _, _, _, err := swe.GetCurrentFileData(5)
+	if want := (swego.Error{Code: -1, Message: "invalid file slot: 5"}); err != want {
+		t.Errorf("err = %q, want: %q", err, want)
+	}
+}
+
 func Test_wrapper_PlanetName(t *testing.T) {
 	t.Parallel()
 
@@ -90,6 +182,31 @@ func Test_wrapper_PlanetName(t *testing.T) {
 	}
 }
 
+func Test_wrapper_PlanetNumber(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want swego.Planet
+		ok   bool
+	}{
+		{"Sun", swego.Sun, true},
+		{"moon", swego.Moon, true},
+		{"mean Node", swego.MeanNode, true},
+		{"Cupido", swego.Cupido, true},
+		{"Nibiru", swego.Nibiru, true},
+		{"Planet X", 0, false},
+	}
+
+	for _, c := range cases {
+		got, ok := swe.PlanetNumber(c.in)
+		if got != c.want || ok != c.ok {
+			t.Errorf("PlanetNumber(%q) = (%d, %t), want: (%d, %t)",
+				c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
 func Test_wrapper_Calc(t *testing.T) {
 	t.Parallel()
 
@@ -174,12 +291,26 @@ func Test_wrapper_Calc(t *testing.T) {
 func Test_wrapper_Calc_error(t *testing.T) {
 	t.Parallel()
 
+	const jplRange = " outside JPL eph. range -3027215.50 .. 7930192.50;"
+
 	cases := []struct {
 		fn  func(float64, swego.Planet, *swego.CalcFlags) ([]float64, int, error)
-		err swego.Error
+		err *swego.DateRangeError
 	}{
-		{swe.Calc, "jd 99999999.000000 outside JPL eph. range -3027215.50 .. 7930192.50;"},
-		{swe.CalcUT, "jd 100002682.057840 outside JPL eph. range -3027215.50 .. 7930192.50;"},
+		{swe.Calc, &swego.DateRangeError{
+			JD:        99999999,
+			Start:     -3027215.5,
+			End:       7930192.5,
+			Ephemeris: "JPL eph.",
+			Message:   "jd 99999999.000000" + jplRange,
+		}},
+		{swe.CalcUT, &swego.DateRangeError{
+			JD:        100002682.05784,
+			Start:     -3027215.5,
+			End:       7930192.5,
+			Ephemeris: "JPL eph.",
+			Message:   "jd 100002682.057840" + jplRange,
+		}},
 	}
 
 	fl := &swego.CalcFlags{
@@ -189,10 +320,236 @@ func Test_wrapper_Calc_error(t *testing.T) {
 	for _, c := range cases {
 		t.Run("", func(t *testing.T) {
 			xx, cfl, err := c.fn(99999999., swego.Sun, fl)
+			if !reflect.DeepEqual(err, c.err) {
+				t.Errorf("err = %#v, want: %#v", err, c.err)
+			}
+
+			if !errors.Is(err, swego.ErrDateOutOfRange) {
+				t.Errorf("errors.Is(%v, ErrDateOutOfRange) = false, want: true", err)
+			}
+
+			if !reflect.DeepEqual(xx, make([]float64, 6)) {
+				t.Errorf("xx = %v, want: []float{}", xx)
+			}
+
+			if cfl != -1 {
+				t.Errorf("cfl = %d, want: -1", cfl)
+			}
+		})
+	}
+}
+
+func Test_wrapper_Calc_errorMoshier(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	_, _, err := swe.Calc(99999999., swego.Mars, fl)
+
+	var e *swego.DateRangeError
+	if !errors.As(err, &e) {
+		t.Fatalf("err = %#v, want: *DateRangeError", err)
+	}
+
+	if e.Ephemeris != "Moshier planet" || e.JD != 99999999 || e.Start >= e.End {
+		t.Errorf("err = %#v, want Moshier planet range", e)
+	}
+}
+
+func Test_wrapper_dateRangeErrorMoshier(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	errs := map[string]error{}
+	_, _, _, _, errs["NodAps"] = swe.NodAps(99999999., swego.Mars, fl, swego.NodbitMean)
+	_, errs["Pheno"] = swe.Pheno(99999999., swego.Mars, fl)
+	_, errs["OrbitalElements"] = swe.OrbitalElements(99999999., swego.Mars, fl)
+	_, errs["HelioCross"] = swe.HelioCross(swego.Mars, 0, 99999999., fl, false)
+
+	for name, err := range errs {
+		var e *swego.DateRangeError
+		if !errors.As(err, &e) {
+			t.Errorf("%s() err = %#v, want: *DateRangeError", name, err)
+		}
+	}
+}
+
+func Test_wrapper_Calc_topoLoc(t *testing.T) {
+	t.Parallel()
+
+	fl := swego.NewCalcFlags().Topocentric(52.083333, 5.116667, 100000)
+	fl.SetEphemeris(swego.Moshier)
+
+	want := swego.Error{Code: -1, Message: "invalid geographic location: altitude 100000"}
+	if _, _, err := swe.CalcUT(2451545, swego.Moon, fl); err != want {
+		t.Errorf("CalcUT() err = %v, want: %v", err, want)
+	}
+
+	if _, err := swe.RiseTrans(2451545, swego.Moon, "", fl, swego.CalcRise, &swego.GeoLoc{}, 0, 0); err != want {
+		t.Errorf("RiseTrans() err = %v, want: %v", err, want)
+	}
+
+	fl.TopoLoc.Alt = 400
+	if _, _, err := swe.CalcUT(2451545, swego.Moon, fl); err != nil {
+		t.Errorf("CalcUT() err = %v, want: nil", err)
+	}
+}
+
+func Test_wrapper_CalcPctr(t *testing.T) {
+	t.Parallel()
+
+	// Without light deflection the position of Mars as seen from the Earth is
+	// the geocentric position.
+	fl := swego.NewCalcFlags().Speed().Ephemeris(swego.Moshier)
+	fl.Flags |= swego.FlagNoGDefl
+
+	want, wantCfl, err := swe.Calc(2451545, swego.Mars, fl)
+	if err != nil {
+		t.Fatalf("Calc() err = %v", err)
+	}
+
+	xx, cfl, err := swe.CalcPctr(2451545, swego.Mars, swego.Earth, fl)
+	if err != nil {
+		t.Fatalf("CalcPctr() err = %v", err)
+	}
+
+	if cfl != wantCfl {
+		t.Errorf("cfl = %d, want: %d", cfl, wantCfl)
+	}
+
+	for i := range want {
+		if !inDelta(xx[i], want[i], 1e-6) {
+			t.Errorf("xx[%d] = %f, want: %f", i, xx[i], want[i])
+		}
+	}
+}
+
+func Test_wrapper_CalcPctr_error(t *testing.T) {
+	t.Parallel()
+
+	fl := swego.NewCalcFlags().Ephemeris(swego.Moshier)
+	cases := []struct {
+		pl, plctr swego.Planet
+		err       string
+	}{
+		{swego.Mars, swego.Mars, "ipl and iplctr (= 4) must not be identical"},
+		{swego.MeanNode, swego.Earth, "planetocentric position not possible for object 10 = mean Node"},
+		{swego.Mars, swego.TrueNode, "planetocentric position not possible for object 11 = true Node"},
+	}
+
+	for _, c := range cases {
+		xx, cfl, err := swe.CalcPctr(2451545, c.pl, c.plctr, fl)
+		want := swego.Error{Code: -1, Message: c.err}
+		if err != want {
+			t.Errorf("CalcPctr(%d, %d) err = %v, want: %v", c.pl, c.plctr, err, want)
+		}
+
+		if !reflect.DeepEqual(xx, make([]float64, 6)) || cfl != -1 {
+			t.Errorf("CalcPctr(%d, %d) = %v, %d, want: zeros, -1", c.pl, c.plctr, xx, cfl)
+		}
+	}
+}
+
+func Test_wrapper_Calc_warning(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags:   swego.FlagEphJPL,
+		JPLFile: "nonexistent.eph",
+	}
+
+	xx, cfl, err := swe.Calc(2451544.5, swego.Sun, fl)
+	if !swego.IsWarning(err) {
+		t.Fatalf("err = %v, want: warning", err)
+	}
+
+	if cfl&swego.FlagEphJPL != 0 {
+		t.Errorf("cfl = %d, want: no JPL flag", cfl)
+	}
+
+	if !inDelta(xx[0], 279.858461, 1e-3) {
+		t.Errorf("xx[0] = %f ± 1e-3, want: 279.858461", xx[0])
+	}
+}
+
+func Test_wrapper_FixStar(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		name string
+		xx   []float64
+		cfl  int
+	}
+
+	// Spica is provided by the C library even if there is no star file.
+	cases := []struct {
+		fn   func(string, float64, *swego.CalcFlags) (string, []float64, int, error)
+		in   string
+		want result
+	}{
+		{swe.FixStar, "Spica",
+			result{"Spica,alVir", []float64{203.836077, -2.054288, 1, .0, .0, .0}, 4}},
+		{swe.FixStarUT, "spica",
+			result{"Spica,alVir", []float64{203.836077, -2.054288, 1, .0, .0, .0}, 4}},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			name, xx, cfl, err := c.fn(c.in, 2451544.5, fl)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if name != c.want.name {
+				t.Errorf("name = %q, want: %q", name, c.want.name)
+			}
+
+			if !inDeltaSlice(xx, c.want.xx, 1e-6) {
+				t.Errorf("xx = %v ± 1e-6, want: %v", xx, c.want.xx)
+			}
+
+			if cfl != c.want.cfl {
+				t.Errorf("cfl = %d, want: %d", cfl, c.want.cfl)
+			}
+		})
+	}
+}
+
+func Test_wrapper_FixStar_error(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		fn  func(string, float64, *swego.CalcFlags) (string, []float64, int, error)
+		err swego.Error
+	}{
+		{swe.FixStar, swego.Error{Code: -1, Message: "swe_fixstar(): star name empty"}},
+		{swe.FixStarUT, swego.Error{Code: -1, Message: "swe_fixstar(): star name empty"}},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			name, xx, cfl, err := c.fn("", 2451544.5, fl)
 			if err != c.err {
 				t.Errorf("err = %v, want: %q", err, c.err)
 			}
 
+			if name != "" {
+				t.Errorf("name = %q, want: \"\"", name)
+			}
+
 			if !reflect.DeepEqual(xx, make([]float64, 6)) {
 				t.Errorf("xx = %v, want: []float{}", xx)
 			}
@@ -204,6 +561,110 @@ func Test_wrapper_Calc_error(t *testing.T) {
 	}
 }
 
+func Test_wrapper_FixStar2(t *testing.T) {
+	t.Parallel()
+
+	const stars = `# test star file
+Aldebaran,alTau,ICRS,04,35,55.2387,+16,30,33.485,63.45,-188.94,54.26,48.94,0.86,16,629
+# Spica is also provided by the C library
+Spica,alVir,ICRS,13,25,11.5793,-11,09,40.759,-42.50,-31.73,1.0,12.44,1.04,-10,3672
+Regulus,alLeo,ICRS,10,08,22.3107,+11,58,01.951,-248.73,5.59,5.9,41.13,1.40,12,2149
+Alcyone,eta Tau,ICRS,03,47,29.0765,+24,06,18.494,19.34,-43.67,5.4,8.09,2.87,23,541
+Cor Leonis,alLeo,ICRS,10,08,22.3107,+11,58,01.951,-248.73,5.59,5.9,41.13,1.40,12,2149
+`
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "sefstars.txt"), []byte(stars), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	// FixStar2 must find the same star as FixStar, which scans the file.
+	queries := []string{
+		"Aldebaran", "aldeb", "ALCYONE", "Cor Leonis", "corleo", "regulus,alTau",
+		",alLeo", ",eta Tau", ",etaTau", "1", "3", "5", "6", "Unknown", ",xxx",
+		"",
+	}
+
+	Locked(swe, func(swe Library) {
+		defer swe.SetPath(DefaultPath)
+		swe.SetPath(dir)
+
+		for _, q := range queries {
+			for _, fn := range []struct {
+				fixStar, fixStar2 func(string, float64, *swego.CalcFlags) (string, []float64, int, error)
+			}{
+				{swe.FixStar, swe.FixStar2},
+				{swe.FixStarUT, swe.FixStar2UT},
+			} {
+				// The C library caches the last star found, look up
+				// another star to use the star file and the index.
+				swe.FixStar("4", 2451544.5, fl)
+				name, xx, cfl, err := fn.fixStar(q, 2451544.5, fl)
+				swe.FixStar("4", 2451544.5, fl)
+				name2, xx2, cfl2, err2 := fn.fixStar2(q, 2451544.5, fl)
+
+				if name2 != name || !reflect.DeepEqual(xx2, xx) || cfl2 != cfl || err2 != err {
+					t.Errorf("FixStar2(%q) = %q, %v, %d, %v, want: %q, %v, %d, %v",
+						q, name2, xx2, cfl2, err2, name, xx, cfl, err)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkFixStar2(b *testing.B) {
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	// all stars of the star file in the default ephemeris path
+	var stars []string
+	for i := 1; ; i++ {
+		name, _, _, err := swe.FixStar2(fmt.Sprint(i), 2451544.5, fl)
+		if err != nil {
+			break
+		}
+
+		stars = append(stars, name)
+	}
+
+	if len(stars) == 0 {
+		b.Skip("no star file in " + DefaultPath)
+	}
+
+	run := func(b *testing.B, fn func(string, float64, *swego.CalcFlags) (string, []float64, int, error)) {
+		for i := 0; i < b.N; i++ {
+			if _, _, _, err := fn(stars[i%len(stars)], 2451544.5, fl); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	b.Run("FixStar", func(b *testing.B) { run(b, swe.FixStar) })
+	b.Run("FixStar2", func(b *testing.B) { run(b, swe.FixStar2) })
+}
+
+func Test_wrapper_FixStarMag_error(t *testing.T) {
+	t.Parallel()
+
+	name, mag, err := swe.FixStarMag("")
+	if err == nil {
+		t.Error("err = nil, want: error")
+	}
+
+	if name != "" {
+		t.Errorf("name = %q, want: \"\"", name)
+	}
+
+	if mag != 0 {
+		t.Errorf("mag = %f, want: 0", mag)
+	}
+}
+
 func Test_wrapper_NodAps(t *testing.T) {
 	t.Parallel()
 
@@ -277,10 +738,22 @@ func Test_wrapper_NodAps_error(t *testing.T) {
 
 	cases := []struct {
 		fn  func(float64, swego.Planet, *swego.CalcFlags, swego.NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error)
-		err swego.Error
+		err *swego.DateRangeError
 	}{
-		{swe.NodAps, "jd 99999999.000000 outside JPL eph. range -3027215.50 .. 7930192.50;"},
-		{swe.NodApsUT, "jd 100002682.057840 outside JPL eph. range -3027215.50 .. 7930192.50;"},
+		{swe.NodAps, &swego.DateRangeError{
+			JD:        99999999,
+			Start:     -3027215.5,
+			End:       7930192.5,
+			Ephemeris: "JPL eph.",
+			Message:   "jd 99999999.000000 outside JPL eph. range -3027215.50 .. 7930192.50;",
+		}},
+		{swe.NodApsUT, &swego.DateRangeError{
+			JD:        100002682.05784,
+			Start:     -3027215.5,
+			End:       7930192.5,
+			Ephemeris: "JPL eph.",
+			Message:   "jd 100002682.057840 outside JPL eph. range -3027215.50 .. 7930192.50;",
+		}},
 	}
 
 	fl := &swego.CalcFlags{
@@ -290,8 +763,8 @@ func Test_wrapper_NodAps_error(t *testing.T) {
 	for _, c := range cases {
 		t.Run("", func(t *testing.T) {
 			nasc, ndsc, peri, aphe, err := c.fn(99999999., swego.Moon, fl, swego.NodbitMean)
-			if err != c.err {
-				t.Errorf("err = %v, want: %q", err, c.err)
+			if !reflect.DeepEqual(err, c.err) {
+				t.Errorf("err = %#v, want: %#v", err, c.err)
 			}
 
 			if !reflect.DeepEqual(nasc, make([]float64, 6)) {
@@ -313,27 +786,288 @@ func Test_wrapper_NodAps_error(t *testing.T) {
 	}
 }
 
-func Test_wrapper_GetAyanamsaEx(t *testing.T) {
+func Test_wrapper_Pheno(t *testing.T) {
 	t.Parallel()
 
 	cases := []struct {
-		fn   func(float64, *swego.AyanamsaExFlags) (float64, error)
-		want float64
+		fn   func(float64, swego.Planet, *swego.CalcFlags) ([]float64, error)
+		want []float64
 	}{
-		{swe.GetAyanamsaEx, 24.740393},
-		{swe.GetAyanamsaExUT, 24.740393},
+		{swe.Pheno, []float64{117.163202, .271737, 62.697793, .496836, -8.828339}},
+		{swe.PhenoUT, []float64{117.171358, .271673, 62.689646, .496833, -8.827904}},
 	}
 
-	fl := &swego.AyanamsaExFlags{
-		Flags:   1,
-		SidMode: new(swego.SidMode),
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
 	}
 
 	for _, c := range cases {
 		t.Run("", func(t *testing.T) {
-			got, err := c.fn(2451544.5, fl)
+			attr, err := c.fn(2451544.5, swego.Moon, fl)
 			if err != nil {
-				t.Fatalf("err = %v, want: nil", err)
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if len(attr) != 20 {
+				t.Fatalf("len(attr) = %d, want: 20", len(attr))
+			}
+
+			if !inDeltaSlice(attr[:5], c.want, 1e-6) {
+				t.Errorf("attr[:5] = %v ± 1e-6, want: %v", attr[:5], c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_OrbitalElements(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	dret, err := swe.OrbitalElements(2451545.0, swego.Mars, fl)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	if len(dret) != 50 {
+		t.Fatalf("len(dret) = %d, want: 50", len(dret))
+	}
+
+	want := []float64{1.523676, .093313, 1.849888, 49.561885, 286.536960,
+		336.098845, 19.357026, 23.333764, 21.299053, 355.455871, 1.880856,
+		.524041, 1.880795, 779.952497, 2451508.062008, 1.381496, 1.665855}
+	if !inDeltaSlice(dret[:17], want, 1e-6) {
+		t.Errorf("dret[:17] = %v ± 1e-6, want: %v", dret[:17], want)
+	}
+
+	_, err = swe.OrbitalElements(2451545.0, swego.Sun, fl)
+	if want := (swego.Error{Code: -1, Message: "error in swe_get_orbital_elements(): object 0 not valid\n"}); err != want {
+		t.Errorf("err = %q, want: %q", err, want)
+	}
+}
+
+func Test_wrapper_OrbitMaxMinTrueDistance(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	dmax, dmin, dtrue, err := swe.OrbitMaxMinTrueDistance(2451545.0, swego.Mars, fl)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	if !inDelta(dmax, 2.676001, 1e-6) {
+		t.Errorf("dmax = %f ± 1e-6, want: 2.676001", dmax)
+	}
+
+	if !inDelta(dmin, .372825, 1e-6) {
+		t.Errorf("dmin = %f ± 1e-6, want: 0.372825", dmin)
+	}
+
+	if !inDelta(dtrue, 1.849612, 1e-6) {
+		t.Errorf("dtrue = %f ± 1e-6, want: 1.849612", dtrue)
+	}
+
+	_, _, _, err = swe.OrbitMaxMinTrueDistance(2451545.0, swego.MeanNode, fl)
+	if want := (swego.Error{Code: -1, Message: "error in swe_get_orbital_elements(): object 10 not valid\n"}); err != want {
+		t.Errorf("err = %q, want: %q", err, want)
+	}
+}
+
+func Test_wrapper_SolCross(t *testing.T) {
+	t.Parallel()
+
+	// March equinoxes of 2000 (07:35 UT), 2010 (17:32 UT) and 2020 (03:50 UT)
+	cases := []struct {
+		fn       func(float64, float64, *swego.CalcFlags, bool) (float64, error)
+		jd       float64
+		backward bool
+		want     float64
+	}{
+		{swe.SolCrossUT, 2451544.5, false, 2451623.816155},
+		{swe.SolCrossUT, 2451744.5, true, 2451623.816155},
+		{swe.SolCrossUT, 2455197.5, false, 2455276.230703},
+		{swe.SolCrossUT, 2458849.5, false, 2458928.659451},
+		{swe.SolCross, 2451544.5, false, 2451623.816895},
+		{swe.SolCross, 2451744.5, true, 2451623.816895},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := c.fn(0, c.jd, fl, c.backward)
+			if err != nil {
+				t.Fatalf("err = %v, want: nil", err)
+			}
+
+			if !inDelta(got, c.want, 1e-6) {
+				t.Errorf("jd = %f ± 1e-6, want: %f", got, c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_MoonCross(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		fn   func(float64, float64, *swego.CalcFlags) (float64, error)
+		want float64
+	}{
+		{swe.MoonCross, 2451556.284319},
+		{swe.MoonCrossUT, 2451556.283581},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := c.fn(0, 2451544.5, fl)
+			if err != nil {
+				t.Fatalf("err = %v, want: nil", err)
+			}
+
+			if !inDelta(got, c.want, 1e-6) {
+				t.Errorf("jd = %f ± 1e-6, want: %f", got, c.want)
+			}
+
+			// consecutive crossings are about one tropical month apart
+			for i := 0; i < 12; i++ {
+				next, err := c.fn(0, got+1, fl)
+				if err != nil {
+					t.Fatalf("err = %v, want: nil", err)
+				}
+
+				if d := next - got; d < 27 || d > 27.7 {
+					t.Errorf("next crossing after %f days, want: between 27 and 27.7", d)
+				}
+
+				got = next
+			}
+		})
+	}
+}
+
+func Test_wrapper_MoonCrossNode(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		jd, xlon float64
+	}
+
+	cases := []struct {
+		fn   func(float64, *swego.CalcFlags) (float64, float64, float64, error)
+		want []result
+	}{
+		{swe.MoonCrossNode, []result{{2451551.757613, 303.651565}}},
+		{swe.MoonCrossNodeUT, []result{
+			{2451551.756874, 303.651565},
+			{2451564.912689, 123.682966},
+			{2451579.039414, 303.749045},
+		}},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			jd := 2451544.5
+
+			for _, want := range c.want {
+				got, xlon, xlat, err := c.fn(jd, fl)
+				if err != nil {
+					t.Fatalf("err = %v, want: nil", err)
+				}
+
+				if !inDelta(got, want.jd, 1e-6) {
+					t.Errorf("jd = %f ± 1e-6, want: %f", got, want.jd)
+				}
+
+				if !inDelta(xlon, want.xlon, 1e-6) {
+					t.Errorf("xlon = %f ± 1e-6, want: %f", xlon, want.xlon)
+				}
+
+				if !inDelta(xlat, 0, 1e-6) {
+					t.Errorf("xlat = %f ± 1e-6, want: 0", xlat)
+				}
+
+				jd = got
+			}
+		})
+	}
+}
+
+func Test_wrapper_HelioCross(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		fn       func(swego.Planet, float64, float64, *swego.CalcFlags, bool) (float64, error)
+		pl       swego.Planet
+		backward bool
+		want     float64
+	}{
+		{swe.HelioCross, swego.Mars, false, 2451545.897491},
+		{swe.HelioCross, swego.Mars, true, 2450858.937341},
+		{swe.HelioCrossUT, swego.Mars, false, 2451545.896752},
+		{swe.HelioCrossUT, swego.Mars, true, 2450858.936611},
+		{swe.HelioCrossUT, swego.Earth, false, 2451810.227489},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := c.fn(c.pl, 0, 2451544.5, fl, c.backward)
+			if err != nil {
+				t.Fatalf("err = %v, want: nil", err)
+			}
+
+			if !inDelta(got, c.want, 1e-6) {
+				t.Errorf("jd = %f ± 1e-6, want: %f", got, c.want)
+			}
+		})
+	}
+
+	_, err := swe.HelioCross(swego.Sun, 0, 2451544.5, fl, false)
+	if want := (swego.Error{Code: -1, Message: "heliocentric crossing not possible for object 0 = Sun"}); err != want {
+		t.Errorf("err = %q, want: %q", err, want)
+	}
+}
+
+func Test_wrapper_GetAyanamsaEx(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		fn   func(float64, *swego.AyanamsaExFlags) (float64, error)
+		want float64
+	}{
+		{swe.GetAyanamsaEx, 24.740393},
+		{swe.GetAyanamsaExUT, 24.740393},
+	}
+
+	fl := &swego.AyanamsaExFlags{
+		Flags:   1,
+		SidMode: new(swego.SidMode),
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := c.fn(2451544.5, fl)
+			if err != nil {
+				t.Fatalf("err = %v, want: nil", err)
 			}
 
 			if !inDelta(got, c.want, 1e-6) {
@@ -370,6 +1104,36 @@ func Test_wrapper_JulDay(t *testing.T) {
 	}
 }
 
+func Test_wrapper_DateConversion(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		y, m, d int
+		ct      swego.CalType
+		want    float64
+		err     error
+	}{
+		{2000, 1, 1, swego.Gregorian, 2451544.5, nil},
+		{2000, 2, 29, swego.Gregorian, 2451603.5, nil},
+		{2000, 2, 30, swego.Gregorian, 2451604.5, swego.ErrInvalidDate},
+		{1900, 2, 29, swego.Gregorian, 2415079.5, swego.ErrInvalidDate},
+		{1900, 2, 29, swego.Julian, 2415091.5, nil},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := swe.DateConversion(c.y, c.m, c.d, 0, c.ct)
+			if err != c.err {
+				t.Errorf("err = %v, want: %v", err, c.err)
+			}
+
+			if !inDelta(got, c.want, 1e-6) {
+				t.Errorf("JD = %f, want: %f", got, c.want)
+			}
+		})
+	}
+}
+
 func Test_wrapper_RevJul(t *testing.T) {
 	t.Parallel()
 
@@ -506,6 +1270,30 @@ func Test_wrapper_JDUT1ToUTC(t *testing.T) {
 	}
 }
 
+func Test_wrapper_DayOfWeek(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		jd   float64
+		want int
+	}{
+		{2451544.5, 5}, // Saturday
+		{2451545.5, 6}, // Sunday
+		{2451546.5, 0}, // Monday
+	}
+
+	for _, c := range cases {
+		got, err := swe.DayOfWeek(c.jd)
+		if err != nil {
+			t.Errorf("err = %v, want: nil", err)
+		}
+
+		if got != c.want {
+			t.Errorf("DayOfWeek(%f) = %d, want: %d", c.jd, got, c.want)
+		}
+	}
+}
+
 func Test_wrapper_HousesEx(t *testing.T) {
 	t.Parallel()
 
@@ -551,7 +1339,7 @@ func Test_wrapper_HousesEx(t *testing.T) {
 					196.367263, 352.493044, 195.452718, 172.493044,
 					.0, .0,
 				},
-				swego.Error(""),
+				swego.ErrHouseFallback,
 			}},
 		{
 			input{52.083333, swego.Gauquelin, nil},
@@ -608,7 +1396,7 @@ func Test_wrapper_HousesEx(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run("", func(t *testing.T) {
-			cusps, ascmc, err := swe.HousesEx(2451544.5, c.in.flags, c.in.geolat, 5.116667, c.in.hsys)
+			cusps, ascmc, err := swe.HousesEx(2451544.5, c.in.flags, &swego.GeoLoc{Lat: c.in.geolat, Long: 5.116667}, c.in.hsys)
 			if err != c.want.err {
 				t.Fatalf("(%f, %c) err = %v, want: %q",
 					c.in.geolat, c.in.hsys, err, c.want.err)
@@ -667,7 +1455,7 @@ func Test_wrapper_HousesArmc(t *testing.T) {
 				196.367450, 352.493777, 195.452830, 172.493777,
 				.0, .0,
 			},
-			swego.Error(""),
+			swego.ErrHouseFallback,
 		}},
 		{input{52.083333, swego.Gauquelin}, result{
 			[]float64{0,
@@ -722,6 +1510,46 @@ func Test_wrapper_HousesArmc(t *testing.T) {
 	}
 }
 
+func Test_wrapper_HousesARMC_fallback(t *testing.T) {
+	t.Parallel()
+
+	for _, hsys := range []swego.HSys{swego.Placidus, swego.Koch, swego.Gauquelin} {
+		_, ascmc, err := swe.HousesARMC(105.080916, 70, 23.439279, hsys)
+		if err != swego.ErrHouseFallback || !swego.IsWarning(err) {
+			t.Errorf("HousesARMC(70, %c) err = %v, want: %v", hsys, err, swego.ErrHouseFallback)
+		}
+
+		if ascmc[swego.MC] == 0 {
+			t.Errorf("HousesARMC(70, %c) MC = 0, want the MC", hsys)
+		}
+	}
+
+	if _, _, err := swe.HousesARMC(105.080916, 70, 23.439279, swego.Porphyrius); err != nil {
+		t.Errorf("HousesARMC(70, O) err = %v, want: nil", err)
+	}
+}
+
+func Test_wrapper_HousesEx_sunshineError(t *testing.T) {
+	t.Parallel()
+
+	// The Sun cannot be computed this far outside of any ephemeris range.
+	loc := &swego.GeoLoc{Lat: 52.083333, Long: 5.116667}
+	for _, hsys := range []swego.HSys{swego.Sunshine, swego.SunshineAlt} {
+		cusps, _, err := swe.HousesEx(-1e8, nil, loc, hsys)
+		if err == nil || swego.IsWarning(err) {
+			t.Errorf("HousesEx(-1e8, %c) err = %v, want: an error", hsys, err)
+		}
+
+		if err == swego.ErrHouseFallback {
+			t.Errorf("HousesEx(-1e8, %c) err = %v, want: not %v", hsys, err, swego.ErrHouseFallback)
+		}
+
+		if len(cusps) != 13 || cusps[1] != 0 {
+			t.Errorf("HousesEx(-1e8, %c) cusps = %v, want: 13 zero cusps", hsys, cusps)
+		}
+	}
+}
+
 func Test_wrapper_HousePos(t *testing.T) {
 	t.Parallel()
 
@@ -742,9 +1570,11 @@ func Test_wrapper_HousePos(t *testing.T) {
 		{input{52.083333, swego.Placidus}, result{6.355326, ""}},
 		{input{82.083333, swego.Koch}, result{6.355326, ""}},
 		{input{52.083333, swego.Gauquelin}, result{20.934023, ""}},
+		{input{0, 'g'}, result{13.868046, ""}},
 		// SunshineAlt is the only lower case house system letter.
 		// It is introduced in Swiss Ephemeris version 2.05.
 		{input{52.083333, swego.SunshineAlt}, result{4.597296, ""}},
+		{input{52.083333, 'Z'}, result{0, "swisseph: invalid house system: HSys(90)"}},
 	}
 	for _, c := range cases {
 		t.Run("", func(t *testing.T) {
@@ -779,12 +1609,60 @@ func Test_wrapper_HouseName(t *testing.T) {
 	}
 }
 
+func Test_wrapper_GauquelinSector(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		m    swego.GauquelinMethod
+		want float64
+	}{
+		{swego.GauquelinWithLat, 24.222677},
+		{swego.GauquelinNoLat, 24.203649},
+		{swego.GauquelinDiscCenter, 24.231042},
+		{swego.GauquelinDiscCenterRefrac, 24.195014},
+		{swego.GauquelinDiscEdge, 24.230997},
+		{swego.GauquelinDiscEdgeRefrac, 24.194969},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	geo := &swego.GeoLoc{Long: 4.9, Lat: 52.37}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := swe.GauquelinSector(2451544.5, swego.Mars, "", fl, c.m, geo, 1013.25, 10)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if !inDelta(got, c.want, 1e-6) {
+				t.Errorf("pos = %f ± 1e-6, want: %f", got, c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_GauquelinSector_error(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	_, err := swe.GauquelinSector(2451544.5, swego.Mars, "", fl, 6, nil, 0, 0)
+	if want := (swego.Error{Code: -1, Message: "invalid method: 6"}); err != want {
+		t.Errorf("err = %q, want: %q", err, want)
+	}
+}
+
 func Test_wrapper_DeltaTEx(t *testing.T) {
 	t.Parallel()
 
 	got, err := swe.DeltaTEx(2451544.5, swego.Swiss)
-	if err != nil {
-		t.Fatalf("err = %v, want: nil", err)
+	if err != nil && !swego.IsWarning(err) {
+		t.Fatalf("err = %v, want: nil or warning", err)
 	}
 
 	if !inDelta(got, 0.000739, 1e-6) {
@@ -796,43 +1674,93 @@ func Test_wrapper_SetDeltaTUserDef(t *testing.T) {
 	t.Parallel()
 
 	Locked(swe, func(swe Library) {
-		const want = 63.8496
-		fl := new(swego.DateConvertFlags)
-		fl.SetDeltaT(want)
-		swe.JdETToUTC(0, fl) // call swe_set_delta_t_userdef
-
-		got, err := swe.DeltaTEx(2451544.5, swego.Swiss)
+		want, err := swe.DeltaTEx(2451544.5, swego.Moshier)
 		if err != nil {
 			t.Fatalf("err = %v, want: nil", err)
 		}
 
-		if got != want {
-			t.Errorf("user defined ΔT not set correctly; ΔT = %f, want: %f", got, want)
-		}
-
-		fl.DeltaT = nil      // reset ΔT library value
+		fl := new(swego.DateConvertFlags)
+		fl.SetDeltaT(63.8496)
 		swe.JdETToUTC(0, fl) // call swe_set_delta_t_userdef
 
-		got, err = swe.DeltaTEx(2451544.5, swego.Swiss)
+		// The ΔT of the flags object only applies to the call it is passed to.
+		got, err := swe.DeltaTEx(2451544.5, swego.Moshier)
 		if err != nil {
 			t.Fatalf("err = %v, want: nil", err)
 		}
 
-		const libValue = 0.000739
-		if !inDelta(got, libValue, 1e-6) {
-			t.Errorf("user defined ΔT not reset correctly; ΔT = %f, want: %f", got, libValue)
+		if got != want {
+			t.Errorf("ΔT of flags kept by DeltaTEx; ΔT = %f, want: %f", got, want)
 		}
 	})
 }
 
-func Test_wrapper_TimeEqu(t *testing.T) {
+func TestCachedDeltaT_flags(t *testing.T) {
 	t.Parallel()
 
 	Locked(swe, func(swe Library) {
-		fl := new(swego.TimeEquFlags)
-		fl.SetDeltaT(0)
-
-		got, err := swe.TimeEqu(2451544.5, fl)
+		want, err := swe.DeltaTEx(2451544.5, swego.Moshier)
+		if err != nil {
+			t.Fatalf("err = %v, want: nil", err)
+		}
+
+		cached := swego.CachedDeltaT(swe)
+		fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+		fl.SetDeltaT(63.8496)
+
+		for i := 0; i < 2; i++ {
+			swe.CalcUT(2451544.5, swego.Sun, fl) // call swe_set_delta_t_userdef
+
+			got, err := cached.DeltaTEx(2451544.5, swego.Moshier)
+			if err != nil {
+				t.Fatalf("err = %v, want: nil", err)
+			}
+
+			if got != want {
+				t.Errorf("DeltaTEx() = %f after CalcUT with ΔT, want: %f", got, want)
+			}
+		}
+	})
+}
+
+func Test_wrapper_SetDeltaTUserDef_default(t *testing.T) {
+	t.Parallel()
+
+	Locked(swe, func(swe Library) {
+		const want = .5
+		swe.SetDeltaTUserDef(want)
+		swe.JdETToUTC(0, new(swego.DateConvertFlags)) // uses the user defined ΔT
+
+		got, err := swe.DeltaTEx(2451544.5, swego.Moshier)
+		if err != nil {
+			t.Fatalf("err = %v, want: nil", err)
+		}
+
+		if got != want {
+			t.Errorf("user defined ΔT not kept; ΔT = %f, want: %f", got, want)
+		}
+
+		swe.SetDeltaTUserDef(swego.DeltaTAutomatic)
+
+		got, err = swe.DeltaTEx(2451544.5, swego.Moshier)
+		if err != nil {
+			t.Fatalf("err = %v, want: nil", err)
+		}
+
+		if got == want {
+			t.Errorf("user defined ΔT not reset; ΔT = %f", got)
+		}
+	})
+}
+
+func Test_wrapper_TimeEqu(t *testing.T) {
+	t.Parallel()
+
+	Locked(swe, func(swe Library) {
+		fl := new(swego.TimeEquFlags)
+		fl.SetDeltaT(0)
+
+		got, err := swe.TimeEqu(2451544.5, fl)
 		if err != nil {
 			t.Fatalf("err = %v, want: nil", err)
 		}
@@ -959,3 +1887,1735 @@ func Test_wrapper_SidTime(t *testing.T) {
 		}
 	})
 }
+
+func Test_wrapper_SetLapseRate(t *testing.T) {
+	t.Parallel()
+
+	geo := &swego.GeoLoc{Long: 8.55, Lat: 47.37, Alt: 2000}
+	in := [3]float64{17.836950, -.735725, 1}
+
+	Locked(swe, func(swe Library) {
+		swe.SetLapseRate(.125) // dip of the horizon above the true altitude
+		got, err := swe.Azalt(2451544.5, swego.Equ2Hor, geo, 1013.25, 15, in, nil)
+		swe.SetLapseRate(swego.LapseRate)
+
+		if err != nil {
+			t.Errorf("err = %v, want: nil", err)
+		}
+
+		if !inDelta(got[2], got[1], 1e-6) {
+			t.Errorf("app alt = %f ± 1e-6, want: %f", got[2], got[1])
+		}
+
+		got, err = swe.Azalt(2451544.5, swego.Equ2Hor, geo, 1013.25, 15, in, nil)
+		if err != nil {
+			t.Errorf("err = %v, want: nil", err)
+		}
+
+		if !inDelta(got[2], -.356735, 1e-6) {
+			t.Errorf("app alt = %f ± 1e-6, want: -0.356735", got[2])
+		}
+	})
+}
+
+func Test_wrapper_Azalt(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		m     swego.AzaltMode
+		press float64
+		in    [3]float64
+		want  []float64
+	}{
+		{swego.Ecl2Hor, 0, [3]float64{280, 0, 1}, []float64{187.469583, -60.532230, -60.532230}},
+		{swego.Equ2Hor, 1013.25, [3]float64{280, -23, 1}, []float64{189.090609, -60.394388, -60.394388}},
+	}
+
+	geo := &swego.GeoLoc{Long: 4.9, Lat: 52.37}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			xaz, err := swe.Azalt(2451544.5, c.m, geo, c.press, 10, c.in, nil)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if !inDeltaSlice(xaz, c.want, 1e-6) {
+				t.Errorf("xaz = %v ± 1e-6, want: %v", xaz, c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_AzaltRev(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		m    swego.AzaltRevMode
+		want []float64
+	}{
+		{swego.Hor2Ecl, []float64{280, 0}},
+		{swego.Hor2Equ, []float64{280.878660, -23.060856}},
+	}
+
+	geo := &swego.GeoLoc{Long: 4.9, Lat: 52.37}
+	in := [2]float64{187.469583, -60.532230}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			xout, err := swe.AzaltRev(2451544.5, c.m, geo, in, nil)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if !inDeltaSlice(xout, c.want, 1e-6) {
+				t.Errorf("xout = %v ± 1e-6, want: %v", xout, c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_Refrac(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		m    swego.RefracMode
+		want float64
+	}{
+		{swego.TrueToApp, 10.088848},
+		{swego.AppToTrue, 9.910518},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := swe.Refrac(10, 1013.25, 15, c.m)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if !inDelta(got, c.want, 1e-6) {
+				t.Errorf("Refrac(10) = %f, want: %f", got, c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_RefracExtended(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		alt  float64
+		dret []float64
+	}
+
+	cases := []struct {
+		m    swego.RefracMode
+		want result
+	}{
+		{swego.TrueToApp, result{10.086732, []float64{10, 10.086732, .086732, -.277780}}},
+		{swego.AppToTrue, result{9.912551, []float64{9.912551, 10, .087449, -.277780}}},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			alt, dret, err := swe.RefracExtended(10, 100, 1013.25, 15, .0065, c.m)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if !inDelta(alt, c.want.alt, 1e-6) {
+				t.Errorf("alt = %f ± 1e-6, want: %f", alt, c.want.alt)
+			}
+
+			if !inDeltaSlice(dret, c.want.dret, 1e-6) {
+				t.Errorf("dret = %v ± 1e-6, want: %v", dret, c.want.dret)
+			}
+		})
+	}
+}
+
+func Test_wrapper_RiseTrans(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		m    swego.RiseTransMode
+		want float64
+	}{
+		{swego.CalcRise, 2451544.826689},
+		{swego.CalcSet, 2451545.150772},
+		{swego.CalcMTransit, 2451544.988667},
+		{swego.CalcITransit, 2451545.488831},
+		{swego.CalcRise | swego.BitCivilTwilight, 2451544.798225},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	geo := &swego.GeoLoc{Long: 4.9, Lat: 52.37}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := swe.RiseTrans(2451544.5, swego.Sun, "", fl, c.m, geo, 1013.25, 10)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if !inDelta(got, c.want, 1e-6) {
+				t.Errorf("tret = %f ± 1e-6, want: %f", got, c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_RiseTrans_error(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		geo *swego.GeoLoc
+		err swego.Error
+	}{
+		{&swego.GeoLoc{Lat: 80}, swego.ErrNoRiseSet},
+		{&swego.GeoLoc{Lat: 52.37, Alt: 30000}, swego.Error{Code: -1, Message: "location for swe_rise_trans() must be between -500 and 25000 m above sea"}},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := swe.RiseTrans(2451544.5, swego.Sun, "", fl, swego.CalcRise, c.geo, 1013.25, 10)
+			if err != c.err {
+				t.Errorf("err = %v, want: %q", err, c.err)
+			}
+
+			if got != 0 {
+				t.Errorf("tret = %f, want: 0", got)
+			}
+		})
+	}
+}
+
+func Test_wrapper_RiseTransTrueHor(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		horhgt float64
+		want   float64
+		err    error
+	}{
+		{0, 2451544.826689, nil},
+		{2, 2451544.840255, nil},
+		{5, 2451544.860579, nil},
+		{20, 0, swego.ErrNoRiseSet},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	geo := &swego.GeoLoc{Long: 4.9, Lat: 52.37}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := swe.RiseTransTrueHor(2451544.5, swego.Sun, "", fl, swego.CalcRise, geo, 1013.25, 10, c.horhgt)
+			if err != c.err {
+				t.Errorf("err = %v, want: %v", err, c.err)
+			}
+
+			if !inDelta(got, c.want, 1e-6) {
+				t.Errorf("tret = %f ± 1e-6, want: %f", got, c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_Cotrans(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   [3]float64
+		eps  float64
+		want []float64
+	}{
+		{[3]float64{280, 1, 1}, -23.4393, []float64{280.797788, -22.065249, 1}},
+		{[3]float64{280.797788, -22.065249, 1}, 23.4393, []float64{280, 1, 1}},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got, err := swe.Cotrans(c.in, c.eps)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if !inDeltaSlice(got, c.want, 1e-6) {
+				t.Errorf("xpn = %v ± 1e-6, want: %v", got, c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_CotransSp(t *testing.T) {
+	t.Parallel()
+
+	in := [6]float64{280, 1, 1, 1, .1, 0}
+	want := []float64{280.797788, -22.065249, 1, 1.067825, .174243, 0}
+
+	got, err := swe.CotransSp(in, -23.4393)
+	if err != nil {
+		t.Errorf("err = %v, want: nil", err)
+	}
+
+	if !inDeltaSlice(got, want, 1e-6) {
+		t.Errorf("xpn = %v ± 1e-6, want: %v", got, want)
+	}
+}
+
+func Test_wrapper_DegNorm(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct{ in, want float64 }{
+		{-30, 330},
+		{725, 5},
+		{360, 0},
+	}
+
+	for _, c := range cases {
+		got, err := swe.DegNorm(c.in)
+		if err != nil {
+			t.Errorf("err = %v, want: nil", err)
+		}
+
+		if !inDelta(got, c.want, 1e-6) {
+			t.Errorf("DegNorm(%f) = %f, want: %f", c.in, got, c.want)
+		}
+	}
+}
+
+func Test_wrapper_RadNorm(t *testing.T) {
+	t.Parallel()
+
+	got, err := swe.RadNorm(-math.Pi / 2)
+	if err != nil {
+		t.Errorf("err = %v, want: nil", err)
+	}
+
+	if want := 3 * math.Pi / 2; !inDelta(got, want, 1e-6) {
+		t.Errorf("RadNorm(-π/2) = %f, want: %f", got, want)
+	}
+}
+
+func Test_wrapper_DegMidp(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct{ x1, x0, want float64 }{
+		{350, 10, 0}, // shorter arc crosses 0°
+		{10, 350, 0},
+		{100, 10, 55},
+		{10, 200, 285},
+	}
+
+	for _, c := range cases {
+		got, err := swe.DegMidp(c.x1, c.x0)
+		if err != nil {
+			t.Errorf("err = %v, want: nil", err)
+		}
+
+		if !inDelta(got, c.want, 1e-6) {
+			t.Errorf("DegMidp(%f, %f) = %f, want: %f", c.x1, c.x0, got, c.want)
+		}
+	}
+}
+
+func Test_wrapper_RadMidp(t *testing.T) {
+	t.Parallel()
+
+	got, err := swe.RadMidp(6, .5)
+	if err != nil {
+		t.Errorf("err = %v, want: nil", err)
+	}
+
+	if want := .108407; !inDelta(got, want, 1e-6) {
+		t.Errorf("RadMidp(6, .5) = %f, want: %f", got, want)
+	}
+}
+
+func Test_wrapper_SplitDeg(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		deg, min, sec int
+		secfr         float64
+		sign          int
+	}
+
+	cases := []struct {
+		x    float64
+		fl   swego.SplitDegFlags
+		want result
+	}{
+		{123.456789, 0, result{123, 27, 24, .4404, 1}},
+		{-123.456789, 0, result{123, 27, 24, .4404, -1}},
+		{123.456789, swego.SplitDegZodiacal | swego.SplitDegRoundSec, result{3, 27, 24, 0, 4}},
+		// rounding carries the seconds into the next sign
+		{59.99999, swego.SplitDegZodiacal | swego.SplitDegRoundSec, result{0, 0, 0, 0, 2}},
+		{59.99999, swego.SplitDegZodiacal | swego.SplitDegRoundSec | swego.SplitDegKeepSign, result{29, 59, 59, 0, 1}},
+		{13.99999, swego.SplitDegRoundSec | swego.SplitDegKeepDeg, result{13, 59, 59, 0, 1}},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			deg, min, sec, secfr, sign, err := swe.SplitDeg(c.x, c.fl)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			got := result{deg, min, sec, secfr, sign}
+			if !inDelta(got.secfr, c.want.secfr, 1e-6) {
+				t.Errorf("secfr = %f ± 1e-6, want: %f", got.secfr, c.want.secfr)
+			}
+
+			got.secfr = c.want.secfr
+			if got != c.want {
+				t.Errorf("SplitDeg(%f, %d) = %v, want: %v", c.x, c.fl, got, c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_HeliacalUT(t *testing.T) {
+	t.Parallel()
+
+	geo := &swego.GeoLoc{Long: 8.55, Lat: 47.37, Alt: 400}
+	atm := &swego.Atmosphere{Press: 1013.25, Temp: 15, RelHumidity: 40, Extinction: .25}
+	obs := &swego.Observer{Age: 36, SnellenRatio: 1}
+	fl := &swego.HeliacalFlags{Flags: swego.FlagEphMoshier}
+
+	dret, err := swe.HeliacalUT(2451544.5, geo, atm, obs, "venus", swego.MorningFirst, fl)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	want := []float64{2451999.692465, 2451999.693865, 2451999.695127}
+	if !inDeltaSlice(dret[:3], want, 1e-6) {
+		t.Errorf("dret[:3] = %v ± 1e-6, want: %v", dret[:3], want)
+	}
+}
+
+func Test_wrapper_HeliacalUT_error(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.HeliacalFlags{Flags: swego.FlagEphMoshier}
+
+	_, err := swe.HeliacalUT(2451544.5, nil, nil, nil, "sun", swego.MorningFirst, fl)
+	if want := (swego.Error{Code: -1, Message: "the sun has no heliacal rising or setting\n"}); err != want {
+		t.Errorf("err = %q, want: %q", err, want)
+	}
+}
+
+func Test_wrapper_HeliacalPhenoUT(t *testing.T) {
+	t.Parallel()
+
+	geo := &swego.GeoLoc{Long: 8.55, Lat: 47.37, Alt: 400}
+	atm := &swego.Atmosphere{Press: 1013.25, Temp: 15, RelHumidity: 40, Extinction: .25}
+	obs := &swego.Observer{Age: 36, SnellenRatio: 1}
+	fl := &swego.HeliacalFlags{Flags: swego.FlagEphMoshier}
+
+	darr, err := swe.HeliacalPhenoUT(2451999.693865, geo, atm, obs, "venus", swego.MorningFirst, fl)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	cases := []struct {
+		i    int
+		want float64
+	}{
+		{swego.HelTopoAltObj, 2.554829},
+		{swego.HelAziObj, 76.812722},
+		{swego.HelTopoAltSun, -5.467183},
+		{swego.HelARCV, 8.030648},
+		{swego.HelTFirst, 2451999.692484},
+		{swego.HelTBest, 2451999.693746},
+		{swego.HelTLast, 2451999.695083},
+		{swego.HelMagnitude, -4.200180},
+		{swego.HelElong, 8.077327},
+	}
+
+	for _, c := range cases {
+		if !inDelta(darr[c.i], c.want, 1e-6) {
+			t.Errorf("darr[%d] = %f ± 1e-6, want: %f", c.i, darr[c.i], c.want)
+		}
+	}
+}
+
+func Test_wrapper_HeliacalPhenoUT_error(t *testing.T) {
+	t.Parallel()
+
+	geo := &swego.GeoLoc{Alt: -1000}
+	fl := &swego.HeliacalFlags{Flags: swego.FlagEphMoshier}
+
+	_, err := swe.HeliacalPhenoUT(2451999.693865, geo, nil, nil, "venus", swego.MorningFirst, fl)
+	if want := (swego.Error{Code: -1, Message: "location for heliacal events must be between -500 and 25000 m above sea"}); err != want {
+		t.Errorf("err = %q, want: %q", err, want)
+	}
+}
+
+func Test_wrapper_VisLimitMag(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		dret    []float64
+		visible bool
+	}
+
+	cases := []struct {
+		ut   float64
+		want result
+	}{
+		{2451999.693865, result{[]float64{-4.182057, 2.554829, 76.812722, -5.467183, 77.767616, -21.570940, 2.914259, -4.200180}, true}},
+		{2451999.5, result{[]float64{-100, 0, 0, 0, 0, 0, 0, 0}, false}}, // below horizon
+	}
+
+	geo := &swego.GeoLoc{Long: 8.55, Lat: 47.37, Alt: 400}
+	atm := &swego.Atmosphere{Press: 1013.25, Temp: 15, RelHumidity: 40, Extinction: .25}
+	obs := &swego.Observer{Age: 36, SnellenRatio: 1}
+	fl := &swego.HeliacalFlags{Flags: swego.FlagEphMoshier}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			dret, visible, err := swe.VisLimitMag(c.ut, geo, atm, obs, "venus", fl)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if visible != c.want.visible {
+				t.Errorf("visible = %t, want: %t", visible, c.want.visible)
+			}
+
+			if !inDeltaSlice(dret, c.want.dret, 1e-6) {
+				t.Errorf("dret = %v ± 1e-6, want: %v", dret, c.want.dret)
+			}
+		})
+	}
+}
+
+func Test_wrapper_VisLimitMag_error(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.HeliacalFlags{Flags: swego.FlagEphMoshier}
+
+	_, visible, err := swe.VisLimitMag(2451999.5, nil, nil, nil, "sun", fl)
+	if want := (swego.Error{Code: -1, Message: "it makes no sense to call swe_vis_limit_mag() for the Sun"}); err != want {
+		t.Errorf("err = %q, want: %q", err, want)
+	}
+
+	if visible {
+		t.Error("visible = true, want: false")
+	}
+}
+
+func Test_wrapper_SolEclipseWhere(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	// total solar eclipse of 1999-08-11
+	geo, attr, typ, err := swe.SolEclipseWhere(2451401.9604166667, fl)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	if want := swego.EclTotal | swego.EclCentral; typ != want {
+		t.Errorf("typ = %d, want: %d", typ, want)
+	}
+
+	wantGeo := []float64{24.272707, 45.086322}
+	if !inDeltaSlice(geo[:2], wantGeo, 1e-6) {
+		t.Errorf("geo[:2] = %v ± 1e-6, want: %v", geo[:2], wantGeo)
+	}
+
+	wantAttr := []float64{1.014108, 1.029487, 1.059844}
+	if !inDeltaSlice(attr[:3], wantAttr, 1e-6) {
+		t.Errorf("attr[:3] = %v ± 1e-6, want: %v", attr[:3], wantAttr)
+	}
+}
+
+func Test_wrapper_SolEclipseHow(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		attr []float64
+		typ  swego.EclType
+	}
+
+	cases := []struct {
+		ut   float64
+		geo  *swego.GeoLoc
+		want result
+	}{
+		{2451401.9604166667, &swego.GeoLoc{Long: 4.9, Lat: 52.37}, result{
+			[]float64{.554040, 1.028194, .455319},
+			swego.EclPartial | swego.EclCentral | swego.EclVisible,
+		}},
+		{2451391.9604166667, &swego.GeoLoc{Long: 4.9, Lat: 52.37}, result{
+			nil, 0, // no eclipse
+		}},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			attr, typ, err := swe.SolEclipseHow(c.ut, fl, c.geo)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if typ != c.want.typ {
+				t.Errorf("typ = %d, want: %d", typ, c.want.typ)
+			}
+
+			if c.want.attr != nil && !inDeltaSlice(attr[:3], c.want.attr, 1e-6) {
+				t.Errorf("attr[:3] = %v ± 1e-6, want: %v", attr[:3], c.want.attr)
+			}
+		})
+	}
+}
+
+func Test_wrapper_SolEclipseHow_error(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	_, _, err := swe.SolEclipseHow(2451401.9604166667, fl, &swego.GeoLoc{Alt: -1000})
+	if want := (swego.Error{Code: -1, Message: "location for eclipses must be between -500 and 25000 m above sea"}); err != want {
+		t.Errorf("err = %q, want: %q", err, want)
+	}
+}
+
+func Test_wrapper_SolEclipseWhenGlob(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		tret []float64
+		typ  swego.EclType
+	}
+
+	cases := []struct {
+		ut       float64
+		typ      swego.EclType
+		backward bool
+		want     result
+	}{
+		{2451180.5, 0, false, result{[]float64{
+			2451225.773326, 2451225.764157, 2451225.661232, 2451225.885550, 2451225.706098,
+			2451225.840693, 2451225.706620, 2451225.840203, 0, 0,
+		}, swego.EclAnnular | swego.EclCentral}},
+		{2451180.5, swego.EclTotal, false, result{[]float64{
+			2451401.960487, 2451401.952232, 2451401.851686, 2451402.069510, 2451401.895816,
+			2451402.025267, 2451401.896168, 2451402.024944, 0, 0,
+		}, swego.EclTotal | swego.EclCentral}},
+		{2451545.5, swego.EclTotal, true, result{[]float64{
+			2451401.960487, 2451401.952232, 2451401.851686, 2451402.069510, 2451401.895816,
+			2451402.025267, 2451401.896168, 2451402.024944, 0, 0,
+		}, swego.EclTotal | swego.EclCentral}},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			tret, typ, err := swe.SolEclipseWhenGlob(c.ut, fl, c.typ, c.backward)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if typ != c.want.typ {
+				t.Errorf("typ = %d, want: %d", typ, c.want.typ)
+			}
+
+			if !inDeltaSlice(tret, c.want.tret, 1e-6) {
+				t.Errorf("tret = %v ± 1e-6, want: %v", tret, c.want.tret)
+			}
+		})
+	}
+}
+
+func Test_wrapper_SolEclipseWhenLoc(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	geo := &swego.GeoLoc{Long: 4.9, Lat: 52.37}
+
+	tret, attr, typ, err := swe.SolEclipseWhenLoc(2451180.5, fl, geo, false)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	wantTyp := swego.EclPartial | swego.EclVisible | swego.EclMaxVisible | swego.Ecl1stVisible | swego.Ecl4thVisible
+	if typ != wantTyp {
+		t.Errorf("typ = %d, want: %d", typ, wantTyp)
+	}
+
+	wantTret := []float64{2451401.935681, 2451401.882063, 0, 0, 2451401.991131, 0, 0}
+	if !inDeltaSlice(tret[:7], wantTret, 1e-6) {
+		t.Errorf("tret[:7] = %v ± 1e-6, want: %v", tret[:7], wantTret)
+	}
+
+	wantAttr := []float64{.931458, 1.028059, .920414}
+	if !inDeltaSlice(attr[:3], wantAttr, 1e-6) {
+		t.Errorf("attr[:3] = %v ± 1e-6, want: %v", attr[:3], wantAttr)
+	}
+}
+
+func Test_wrapper_LunEclipseHow(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		ut   float64
+		attr []float64
+		typ  swego.EclType
+	}{
+		{2451564.696863, []float64{1.325262, 2.306216, 0, 0, 89.304362, 24.888866, 24.923778, .299313}, swego.EclTotal},
+		{2451545.5, nil, 0}, // no eclipse
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	geo := &swego.GeoLoc{Long: 4.9, Lat: 52.37}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			attr, typ, err := swe.LunEclipseHow(c.ut, fl, geo)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if typ != c.typ {
+				t.Errorf("typ = %d, want: %d", typ, c.typ)
+			}
+
+			if c.attr != nil && !inDeltaSlice(attr[:8], c.attr, 1e-6) {
+				t.Errorf("attr[:8] = %v ± 1e-6, want: %v", attr[:8], c.attr)
+			}
+		})
+	}
+}
+
+func Test_wrapper_LunEclipseWhen(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	// The maximum of the total lunar eclipse of 2000-01-21 was at 04:43:37 UT.
+	want, _ := swe.JulDay(2000, 1, 21, 4+43/60.+37/3600., swego.Gregorian)
+
+	tret, typ, err := swe.LunEclipseWhen(2451545.5, fl, swego.EclTotal, false)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	if typ != swego.EclTotal {
+		t.Errorf("typ = %d, want: %d", typ, swego.EclTotal)
+	}
+
+	if !inDelta(tret[0], want, 10./86400) {
+		t.Errorf("tret[0] = %f ± 10s, want: %f", tret[0], want)
+	}
+
+	// search backward from the day after to find the same eclipse
+	tret2, _, err := swe.LunEclipseWhen(tret[0]+1, fl, swego.EclTotal, true)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	if !inDelta(tret2[0], tret[0], 1e-6) {
+		t.Errorf("tret[0] = %f ± 1e-6, want: %f", tret2[0], tret[0])
+	}
+}
+
+func Test_wrapper_LunEclipseWhenLoc(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	geo := &swego.GeoLoc{Long: 4.9, Lat: 52.37}
+
+	tret, attr, typ, err := swe.LunEclipseWhenLoc(2451545.5, fl, geo, false)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	wantTyp := swego.EclTotal | swego.EclVisible | swego.EclMaxVisible | swego.EclPartBegVisible |
+		swego.EclTotBegVisible | swego.EclTotEndVisible | swego.EclPartEndVisible |
+		swego.EclPenumbBegVisible | swego.EclPenumbEndVisible
+	if typ != wantTyp {
+		t.Errorf("typ = %d, want: %d", typ, wantTyp)
+	}
+
+	wantTret := []float64{
+		2451564.696863, 0, 2451564.626232, 2451564.767496, 2451564.670105,
+		2451564.723628, 2451564.586368, 2451564.807412, 0, 0,
+	}
+	if !inDeltaSlice(tret, wantTret, 1e-6) {
+		t.Errorf("tret = %v ± 1e-6, want: %v", tret, wantTret)
+	}
+
+	wantAttr := []float64{1.325262, 2.306216}
+	if !inDeltaSlice(attr[:2], wantAttr, 1e-6) {
+		t.Errorf("attr[:2] = %v ± 1e-6, want: %v", attr[:2], wantAttr)
+	}
+}
+
+func Test_wrapper_LunOccultWhere(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	geo, attr, typ, err := swe.LunOccultWhere(2451607.544842, swego.Venus, "", fl)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	if want := swego.EclTotal | swego.EclCentral; typ != want {
+		t.Errorf("typ = %d, want: %d", typ, want)
+	}
+
+	wantGeo := []float64{158.749549, -57.297124}
+	if !inDeltaSlice(geo[:2], wantGeo, 1e-6) {
+		t.Errorf("geo[:2] = %v ± 1e-6, want: %v", geo[:2], wantGeo)
+	}
+
+	wantAttr := []float64{81.982913, 163.249381, 26650.360487}
+	if !inDeltaSlice(attr[:3], wantAttr, 1e-6) {
+		t.Errorf("attr[:3] = %v ± 1e-6, want: %v", attr[:3], wantAttr)
+	}
+}
+
+func Test_wrapper_LunOccultWhenGlob(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		tret []float64
+		typ  swego.EclType
+	}
+
+	cases := []struct {
+		typ  swego.EclType
+		want result
+	}{
+		{0, result{[]float64{
+			2451607.544842, 2451607.532318, 2451607.456526, 2451607.633375, 2451607.456855,
+			2451607.633046, 2451607.484851, 2451607.605019, 0, 0,
+		}, swego.EclTotal | swego.EclCentral}},
+		// the next conjunction is not an occultation
+		{swego.EclOneTry, result{[]float64{2451547.696483, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 0}},
+	}
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			tret, typ, err := swe.LunOccultWhenGlob(2451545.5, swego.Venus, "", fl, c.typ, false)
+			if err != nil {
+				t.Errorf("err = %v, want: nil", err)
+			}
+
+			if typ != c.want.typ {
+				t.Errorf("typ = %d, want: %d", typ, c.want.typ)
+			}
+
+			if !inDeltaSlice(tret[:1], c.want.tret[:1], 1e-6) {
+				t.Errorf("tret[0] = %f ± 1e-6, want: %f", tret[0], c.want.tret[0])
+			}
+
+			if c.want.typ != 0 && !inDeltaSlice(tret, c.want.tret, 1e-6) {
+				t.Errorf("tret = %v ± 1e-6, want: %v", tret, c.want.tret)
+			}
+		})
+	}
+}
+
+func Test_wrapper_LunOccultWhenLoc(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	geo := &swego.GeoLoc{Long: 158.749212, Lat: -57.297235}
+
+	tret, attr, _, err := swe.LunOccultWhenLoc(2451545.5, swego.Venus, "", fl, geo, false)
+	if err != nil {
+		t.Fatalf("err = %v, want: nil", err)
+	}
+
+	wantTret := []float64{2451607.544887, 2451607.514403, 2451607.514803, 2451607.574254, 2451607.574635, 0, 0}
+	if !inDeltaSlice(tret[:7], wantTret, 1e-6) {
+		t.Errorf("tret[:7] = %v ± 1e-6, want: %v", tret[:7], wantTret)
+	}
+
+	wantAttr := []float64{82.055820, 163.249363, 26650.354459}
+	if !inDeltaSlice(attr[:3], wantAttr, 1e-6) {
+		t.Errorf("attr[:3] = %v ± 1e-6, want: %v", attr[:3], wantAttr)
+	}
+}
+
+func Test_wrapper_LunOccultWhenLoc_error(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{
+		Flags: swego.FlagEphMoshier,
+	}
+
+	_, _, _, err := swe.LunOccultWhenLoc(2451545.5, swego.Venus, "", fl, &swego.GeoLoc{Alt: -1000}, false)
+	if want := (swego.Error{Code: -1, Message: "location for occultations must be between -500 and 25000 m above sea"}); err != want {
+		t.Errorf("err = %q, want: %q", err, want)
+	}
+}
+
+func BenchmarkCachedDeltaT(b *testing.B) {
+	// dense ephemeris run: ΔT of ten bodies in hourly steps over one month
+	const bodies, steps = 10, 31 * 24
+
+	run := func(b *testing.B, swe swego.Interface) {
+		for i := 0; i < b.N; i++ {
+			jd := 2451544.5 + float64(i/bodies%steps)/24
+			if _, err := swe.DeltaTEx(jd, swego.Moshier); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	b.Run("Uncached", func(b *testing.B) { run(b, swe) })
+	b.Run("Cached", func(b *testing.B) { run(b, swego.CachedDeltaT(swe)) })
+}
+
+func TestTimeFromJulDay(t *testing.T) {
+	t.Parallel()
+
+	cases := []time.Time{
+		time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1972, 6, 30, 23, 59, 59, 500e6, time.UTC), // before leap second
+		time.Date(1972, 7, 1, 0, 0, 0, 250e6, time.UTC),     // after leap second
+		time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2016, 12, 31, 23, 59, 59, 999e6, time.UTC), // before leap second
+		time.Date(2017, 1, 1, 0, 0, 0, 1e6, time.UTC),        // after leap second
+		time.Date(2020, 2, 29, 6, 30, 15, 123e6, time.FixedZone("", 3600)),
+		time.Date(2100, 12, 31, 18, 0, 0, 0, time.UTC),
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			jd, err := swego.JulDayFromTime(swe, c)
+			if err != nil {
+				t.Fatalf("JulDayFromTime(%s) err = %q", c, err)
+			}
+
+			got, err := swego.TimeFromJulDay(swe, jd)
+			if err != nil {
+				t.Fatalf("TimeFromJulDay(%f) err = %q", jd, err)
+			}
+
+			if got.Location() != time.UTC {
+				t.Errorf("location = %s, want: UTC", got.Location())
+			}
+
+			if d := got.Sub(c); d < -time.Millisecond || d > time.Millisecond {
+				t.Errorf("TimeFromJulDay(JulDayFromTime(%s)) = %s, want ± 1ms", c, got)
+			}
+		})
+	}
+
+	jd, err := swego.JulDayFromTime(swe, time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("JulDayFromTime err = %q", err)
+	}
+
+	// UT1 differs from UTC by less than a second
+	if !inDelta(jd, 2451545.0, 1.0/86400) {
+		t.Errorf("JulDayFromTime(2000-01-01T12:00:00Z) = %f, want: 2451545.0 ± 1s", jd)
+	}
+}
+
+func TestDeltaTExSeries(t *testing.T) {
+	t.Parallel()
+
+	// the tidal acceleration matters for ancient dates
+	jds := []float64{1000000.5, 1000000.5 + 365.25}
+
+	Locked(swe, func(swe Library) {
+		auto, err := swego.DeltaTExSeries(swe, jds, swego.Moshier)
+		if err != nil {
+			t.Fatalf("DeltaTExSeries() err = %q", err)
+		}
+
+		// a manually set tidal acceleration overrides the one of the ephemeris
+		swe.SetTidAcc(swego.TidalDE200)
+		manual, err := swego.DeltaTExSeries(swe, jds, swego.Moshier)
+		swe.SetTidAcc(swego.TidalAutomatic)
+
+		if err != nil {
+			t.Fatalf("DeltaTExSeries() err = %q", err)
+		}
+
+		if !inDelta(auto[0], 0.532767, 1e-6) {
+			t.Errorf("ΔT = %f, want: 0.532767", auto[0])
+		}
+
+		if !inDelta(manual[0], 0.505361, 1e-6) {
+			t.Errorf("ΔT with DE200 tidal acceleration = %f, want: 0.505361", manual[0])
+		}
+
+		if len(auto) != len(jds) || len(manual) != len(jds) {
+			t.Errorf("len = %d, %d, want: %d", len(auto), len(manual), len(jds))
+		}
+	})
+}
+
+func Test_wrapper_SidTimeModel(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		jd    float64
+		model int
+		want  float64
+	}{
+		{2451545.0, 0, 18.697138},
+		{2451545.0, swego.SidTIAU1976, 18.697138},
+		{2200000.5, swego.SidTIAU1976, 13.753420},
+		{2200000.5, swego.SidTIAU2006, 13.753449},
+		{2200000.5, swego.SidTIERSConv2010, 13.753449},
+		{2200000.5, swego.SidTLongterm, 13.753730},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			got := swe.SidTimeModel(c.jd, c.model, nil)
+			if !inDelta(got, c.want, 1e-6) {
+				t.Errorf("SidTimeModel(%f, %d) = %f, want: %f", c.jd, c.model, got, c.want)
+			}
+		})
+	}
+}
+
+func Test_wrapper_GetAyanamsaExUT_nutation(t *testing.T) {
+	t.Parallel()
+
+	// Lahiri ayanamsa at 2000-01-01 0:00 UT is published as 23°51'11"
+	// (23.8531°), the true ayanamsa including nutation.
+	const jd = 2451544.5
+
+	sm := &swego.SidMode{Mode: swego.SidmLahiri}
+	mean, err := swe.GetAyanamsaExUT(jd, &swego.AyanamsaExFlags{
+		Flags:   swego.FlagEphMoshier,
+		SidMode: sm,
+	})
+
+	if err != nil {
+		t.Fatalf("GetAyanamsaExUT err = %q", err)
+	}
+
+	if !inDelta(mean, 23.857035, 1e-6) {
+		t.Errorf("mean ayanamsa = %f, want: 23.857035", mean)
+	}
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+	nut, _, _ := swe.CalcUT(jd, swego.EclNut, fl)
+	trop, _, _ := swe.CalcUT(jd, swego.Sun, fl)
+	sid, _, _ := swe.CalcUT(jd, swego.Sun, fl.Copy().Sidereal(swego.SidmLahiri))
+
+	if got := trop[0] - sid[0]; !inDelta(got, mean+nut[2], 1e-6) || !inDelta(got, 23.853165, 1e-6) {
+		t.Errorf("true ayanamsa = %f, want: %f (mean + nutation) = 23.853165", got, mean+nut[2])
+	}
+}
+
+func Test_wrapper_SidmUser(t *testing.T) {
+	t.Parallel()
+
+	sm := &swego.SidMode{Mode: swego.SidmUser, T0: 2451545.0, AyanT0: 23.5}
+
+	t.Run("GetAyanamsaEx", func(t *testing.T) {
+		cases := []struct {
+			sm   *swego.SidMode
+			fn   func(float64, *swego.AyanamsaExFlags) (float64, error)
+			jd   float64
+			want float64
+		}{
+			{sm, swe.GetAyanamsaEx, 2451545.0, 23.5},
+			{sm, swe.GetAyanamsaEx, 2451545.0 + 36525, 24.897195},
+			{&swego.SidMode{Mode: swego.SidmUser | swego.SidbitUserUT, T0: 2451545.0, AyanT0: 23.5},
+				swe.GetAyanamsaExUT, 2451545.0, 23.5},
+		}
+
+		for _, c := range cases {
+			got, err := c.fn(c.jd, &swego.AyanamsaExFlags{Flags: swego.FlagEphMoshier, SidMode: c.sm})
+			if err != nil {
+				t.Fatalf("err = %q", err)
+			}
+
+			if !inDelta(got, c.want, 1e-6) {
+				t.Errorf("ayanamsa(%f) = %f, want: %f", c.jd, got, c.want)
+			}
+		}
+	})
+
+	t.Run("Calc", func(t *testing.T) {
+		fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier | swego.FlagNoNut}
+		trop, _, err := swe.Calc(2451545.0, swego.Sun, fl)
+		if err != nil {
+			t.Fatalf("Calc err = %q", err)
+		}
+
+		fl = fl.Copy()
+		fl.Flags |= swego.FlagSidereal
+		fl.SidMode = sm
+
+		sid, _, err := swe.Calc(2451545.0, swego.Sun, fl)
+		if err != nil {
+			t.Fatalf("Calc err = %q", err)
+		}
+
+		if got := trop[0] - sid[0]; !inDelta(got, 23.5, 1e-6) {
+			t.Errorf("tropical - sidereal longitude = %f, want: 23.5", got)
+		}
+	})
+}
+
+func TestAyanamsaName(t *testing.T) {
+	t.Parallel()
+
+	modes := []swego.Ayanamsa{swego.SidmUser, swego.SidmLahiri | swego.SidbitEclT0}
+	for m := swego.SidmFaganBradley; m <= swego.SidmBabylBritton+1; m++ {
+		modes = append(modes, m)
+	}
+
+	for _, m := range modes {
+		want, _ := swe.GetAyanamsaName(m)
+		if got := swego.AyanamsaName(m); got != want {
+			t.Errorf("AyanamsaName(%d) = %q, want: %q", m, got, want)
+		}
+	}
+
+	for m, want := range map[swego.Ayanamsa]string{
+		swego.SidmGalEquIAU1958:   "Galactic Equator (IAU1958)",
+		swego.SidmGalAlignMardyks: "Skydram (Mardyks)",
+		swego.SidmGalTrueMula:     "True Mula (Chandra Hari)",
+	} {
+		if got := swego.AyanamsaName(m); got != want {
+			t.Errorf("AyanamsaName(%d) = %q, want: %q", m, got, want)
+		}
+	}
+}
+
+func TestAyanamsaModes(t *testing.T) {
+	t.Parallel()
+
+	modes, err := swego.AyanamsaModes(swe)
+	if err != nil {
+		t.Fatalf("AyanamsaModes() err = %q", err)
+	}
+
+	if n := int(swego.SidmBabylBritton) + 1; len(modes) != n {
+		t.Fatalf("len(AyanamsaModes()) = %d, want: %d", len(modes), n)
+	}
+
+	for i, m := range modes {
+		if m.Mode != swego.Ayanamsa(i) || m.Name != swego.AyanamsaName(m.Mode) {
+			t.Errorf("AyanamsaModes()[%d] = %v, want: {%d %q}", i, m, i, swego.AyanamsaName(swego.Ayanamsa(i)))
+		}
+	}
+}
+
+func TestHouseSystems(t *testing.T) {
+	t.Parallel()
+
+	systems, err := swego.HouseSystems(swe)
+	if err != nil {
+		t.Fatalf("HouseSystems() err = %q", err)
+	}
+
+	var codes []byte
+	for _, s := range systems {
+		codes = append(codes, byte(s.Code))
+
+		if s.Name != s.Code.String() {
+			t.Errorf("HouseSystems() name of %c = %q, want: %q", s.Code, s.Name, s.Code.String())
+		}
+	}
+
+	if got, want := string(codes), "BCDEFGHIiKLMNOPQRSTUVWXY"; got != want {
+		t.Errorf("HouseSystems() codes = %q, want: %q", got, want)
+	}
+}
+
+func Test_wrapper_SetEphemeris(t *testing.T) {
+	t.Parallel()
+
+	Locked(swe, func(swe Library) {
+		defer swe.SetPath(DefaultPath)
+
+		fl, err := swe.SetEphemeris(swego.Moshier, DefaultPath, "")
+		if err != nil {
+			t.Fatalf("SetEphemeris(Moshier) err = %q", err)
+		}
+
+		if fl.Flags != swego.FlagEphMoshier || fl.JPLFile != "" {
+			t.Errorf("SetEphemeris(Moshier) = %v, want: SEFLG_MOSEPH", fl)
+		}
+
+		fl, err = swe.SetEphemeris(swego.JPL, DefaultPath, "nonexistent.eph")
+		setJPLFile(swego.FnameDft)
+
+		if err == nil {
+			t.Errorf("SetEphemeris(JPL) = %v, want error", fl)
+		}
+	})
+}
+
+func Test_wrapper_Calc_fictitious(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+
+	Locked(swe, func(swe Library) {
+		// built-in elements, without seorbel.txt in the ephemeris path
+		xx, _, err := swe.Calc(2451545.0, swego.Cupido, fl)
+		if err != nil && !swego.IsWarning(err) {
+			t.Errorf("Calc(Cupido) err = %q", err)
+		}
+
+		want := []float64{243.896441, 0.974541, 41.688674, 0, 0, 0}
+		if !inDeltaSlice(xx, want, 1e-6) {
+			t.Errorf("Calc(Cupido) = %v, want: %v", xx, want)
+		}
+
+		// elements read from seorbel.txt
+		dir := t.TempDir()
+		elem := "J2000, J2000, 0, 40, 0, 0, 0, 0, Testbody\n"
+		if err := os.WriteFile(filepath.Join(dir, "seorbel.txt"), []byte(elem), 0644); err != nil {
+			t.Error(err)
+			return
+		}
+
+		swe.SetPath(dir)
+		defer swe.SetPath(DefaultPath)
+
+		name, _ := swe.PlanetName(swego.FictOffset)
+		if name != "Testbody" {
+			t.Errorf("PlanetName(FictOffset) = %q, want: \"Testbody\"", name)
+		}
+
+		_, _, err = swe.Calc(2451545.0, swego.FictOffset, fl)
+		if err != nil {
+			t.Errorf("Calc(FictOffset) err = %q, want: nil", err)
+		}
+	})
+}
+
+func Test_wrapper_CheckEphemerisFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	Locked(swe, func(swe Library) {
+		defer swe.SetPath(DefaultPath)
+		swe.SetPath(dir)
+
+		// The file is created after SetPath, which would try to read it.
+		if err := os.WriteFile(filepath.Join(dir, "sepl_18.se1"), nil, 0644); err != nil {
+			t.Error(err)
+			return
+		}
+
+		got, err := swe.CheckEphemerisFiles(2451544.5, 2451910.5)
+		if err != nil {
+			t.Errorf("CheckEphemerisFiles() err = %q", err)
+		}
+
+		// The empty file can't be read.
+		want := []string{"sepl_18.se1", "semo_18.se1", "seas_18.se1"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("CheckEphemerisFiles() = %q, want: %q", got, want)
+		}
+
+		got, err = swe.CheckEphemerisFiles(2451544.5, 2634166.5)
+		if err != nil {
+			t.Errorf("CheckEphemerisFiles(2000-2500) err = %q", err)
+		}
+
+		want = []string{"sepl_18.se1", "semo_18.se1", "seas_18.se1", "sepl_24.se1", "semo_24.se1", "seas_24.se1"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("CheckEphemerisFiles(2000-2500) = %q, want: %q", got, want)
+		}
+
+		if _, err := swe.CheckEphemerisFiles(2451910.5, 2451544.5); err == nil {
+			t.Error("CheckEphemerisFiles(to before from) err = nil, want error")
+		}
+	})
+}
+
+func TestNutation(t *testing.T) {
+	t.Parallel()
+
+	// Meeus, Astronomical Algorithms, example 22.a: 1987 April 10 at 0h TD.
+	// The values are computed with the IAU 1980 theory of nutation, the
+	// library uses the IAU 2000 theory, which differs by a few milliarc
+	// seconds, and the IAU 2006 mean obliquity, which differs by 0.04".
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+	dpsi, deps, err := swego.Nutation(swe, 2446895.5, fl)
+	if err != nil {
+		t.Fatalf("Nutation() err = %q", err)
+	}
+
+	if !inDelta(dpsi*3600, -3.788, .01) || !inDelta(deps*3600, 9.443, .01) {
+		t.Errorf("Nutation() = (%f\", %f\"), want: (-3.788\", 9.443\")", dpsi*3600, deps*3600)
+	}
+
+	trueEps, meanEps, _, _, _ := swego.Obliquity(swe, 2446895.5, fl)
+	if !inDelta(meanEps, 23.440946, 1e-4) || !inDelta(trueEps, 23.443569, 1e-4) {
+		t.Errorf("Obliquity() = (%f, %f), want: (23.443569, 23.440946)", trueEps, meanEps)
+	}
+}
+
+func TestCalcBarycentric(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+	if _, _, err := swego.CalcBarycentric(swe, 2451545.0, swego.Sun, fl); err == nil {
+		t.Error("CalcBarycentric(Moshier) err = nil, want error")
+	}
+
+	// Equatorial J2000 coordinates of the Sun relative to the solar system
+	// barycenter, from the JPL Horizons system.
+	fl = &swego.CalcFlags{Flags: swego.FlagEphSwiss | swego.FlagXYZ |
+		swego.FlagEquatorial | swego.FlagJ2000 | swego.FlagICRS}
+	xx, _, err := swego.CalcBarycentric(swe, 2451545.0, swego.Sun, fl)
+	if err != nil {
+		t.Skipf("Swiss Ephemeris files not available: %v", err)
+	}
+
+	want := []float64{-7.139e-3, -2.643e-3, -9.21e-4}
+	if !inDeltaSlice(xx[:3], want, 5e-5) {
+		t.Errorf("CalcBarycentric(Sun) = %v, want: %v", xx[:3], want)
+	}
+}
+
+func TestCalcJ2000(t *testing.T) {
+	t.Parallel()
+
+	// A century before J2000 the equinox of date differs from the equinox of
+	// J2000 by the general precession of about 1.397° per century.
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+	ofDate, _, err := swe.Calc(2415020.0, swego.Mars, fl)
+	if err != nil {
+		t.Fatalf("Calc() err = %q", err)
+	}
+
+	j2000, _, err := swego.CalcJ2000(swe, 2415020.0, swego.Mars, fl)
+	if err != nil {
+		t.Fatalf("CalcJ2000() err = %q", err)
+	}
+
+	if d := j2000[0] - ofDate[0]; !inDelta(d, 1.397, .02) {
+		t.Errorf("J2000 - of date = %f, want: 1.397", d)
+	}
+}
+
+func TestLocalMeanToApparent(t *testing.T) {
+	t.Parallel()
+
+	// Around 3 November the apparent Sun is about 16.4 minutes ahead of the
+	// mean Sun.
+	loc := &swego.GeoLoc{Long: 5.116667, Lat: 52.083333}
+	lmt := time.Date(2020, 11, 3, 12, 0, 0, 0, time.FixedZone("LMT", 1228))
+
+	lat, err := swego.LocalMeanToApparent(swe, lmt, loc)
+	if err != nil {
+		t.Fatalf("LocalMeanToApparent() err = %q", err)
+	}
+
+	if d := lat.Sub(lmt); d < 16*time.Minute || d > 17*time.Minute {
+		t.Errorf("LocalMeanToApparent() - LMT = %s, want: about 16m25s", d)
+	}
+
+	if lat.Location() != lmt.Location() {
+		t.Errorf("location = %s, want: %s", lat.Location(), lmt.Location())
+	}
+
+	got, err := swego.LocalApparentToMean(swe, lat, loc)
+	if err != nil {
+		t.Fatalf("LocalApparentToMean() err = %q", err)
+	}
+
+	if d := got.Sub(lmt); d < -time.Second || d > time.Second {
+		t.Errorf("LocalApparentToMean() = %s, want: %s", got, lmt)
+	}
+}
+
+func TestEquationOfTimeMinutes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		jd   float64
+		want float64
+	}{
+		{2458890.5, -14.2}, // 2020-02-11
+		{2459156.5, 16.4},  // 2020-11-03
+	}
+
+	for _, c := range cases {
+		got, err := swego.EquationOfTimeMinutes(swe, c.jd)
+		if err != nil {
+			t.Fatalf("EquationOfTimeMinutes(%f) err = %q", c.jd, err)
+		}
+
+		if !inDelta(got, c.want, .1) {
+			t.Errorf("EquationOfTimeMinutes(%f) = %f, want: %f", c.jd, got, c.want)
+		}
+	}
+}
+
+func TestLocalSiderealTime(t *testing.T) {
+	t.Parallel()
+
+	const ut = 2451545.
+
+	gst, err := swe.SidTime(ut, nil)
+	if err != nil {
+		t.Fatalf("SidTime() err = %q", err)
+	}
+
+	cases := []struct {
+		long float64
+		want float64
+	}{
+		{0, gst},
+		{90, math.Mod(gst+6, 24)},
+		{-90, math.Mod(gst+18, 24)},
+		{180, math.Mod(gst+12, 24)},
+	}
+
+	for _, c := range cases {
+		got, err := swego.LocalSiderealTime(swe, ut, c.long)
+		if err != nil {
+			t.Fatalf("LocalSiderealTime(%f) err = %q", c.long, err)
+		}
+
+		if !inDelta(got, c.want, 1e-9) || got < 0 || got >= 24 {
+			t.Errorf("LocalSiderealTime(%f) = %f, want: %f", c.long, got, c.want)
+		}
+	}
+}
+
+func TestLocalARMC(t *testing.T) {
+	t.Parallel()
+
+	const ut = 2451545.
+	loc := &swego.GeoLoc{Long: -74.006, Lat: 40.7128}
+
+	got, err := swego.LocalARMC(swe, ut, loc.Long)
+	if err != nil {
+		t.Fatalf("LocalARMC() err = %q", err)
+	}
+
+	_, _, want, _, _, err := swego.ChartAngles(swe, ut, loc, swego.Placidus)
+	if err != nil {
+		t.Fatalf("ChartAngles() err = %q", err)
+	}
+
+	if !inDelta(got, want, 1e-6) {
+		t.Errorf("LocalARMC() = %f, want: %f", got, want)
+	}
+}
+
+func TestMoonPhase(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		ut   float64
+		name string
+		illu float64
+	}{
+		{2458849.5, "Waxing Crescent", .300}, // 2020-01-01
+		{2458853.5, "First Quarter", .670},   // 2020-01-05
+		{2458859.5, "Full Moon", .999},       // 2020-01-10
+		{2458865.5, "Last Quarter", .563},    // 2020-01-17
+		{2458870.5, "Waning Crescent", .087}, // 2020-01-20
+	}
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+	for _, c := range cases {
+		illu, name, err := swego.MoonPhase(swe, c.ut, fl)
+		if err != nil {
+			t.Fatalf("MoonPhase(%f) err = %q", c.ut, err)
+		}
+
+		if name != c.name || !inDelta(illu, c.illu, .001) {
+			t.Errorf("MoonPhase(%f) = (%f, %q), want: (%f, %q)", c.ut, illu, name, c.illu, c.name)
+		}
+	}
+}
+
+func TestNextLunarPhase(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+
+	// 2020-01-01, the next full moon is on 2020-01-10 at 19:21 UT.
+	full, err := swego.NextLunarPhase(swe, 2458849.5, swego.FullMoon, fl)
+	if err != nil {
+		t.Fatalf("NextLunarPhase(FullMoon) err = %q", err)
+	}
+
+	if want := 2458859.30625; !inDelta(full, want, 1./1440) {
+		t.Errorf("NextLunarPhase(FullMoon) = %f, want: %f", full, want)
+	}
+
+	if _, name, _ := swego.MoonPhase(swe, full, fl); name != "Full Moon" {
+		t.Errorf("MoonPhase(%f) = %q, want: \"Full Moon\"", full, name)
+	}
+
+	new1, err := swego.NextLunarPhase(swe, 2458849.5, swego.NewMoon, fl)
+	if err != nil {
+		t.Fatalf("NextLunarPhase(NewMoon) err = %q", err)
+	}
+
+	new2, err := swego.NextLunarPhase(swe, new1+1, swego.NewMoon, fl)
+	if err != nil {
+		t.Fatalf("NextLunarPhase(NewMoon) err = %q", err)
+	}
+
+	// The synodic month varies between about 29.27 and 29.83 days.
+	if d := new2 - new1; d < 29.2 || d > 29.9 {
+		t.Errorf("consecutive new moons %f days apart, want: about 29.53", d)
+	}
+}
+
+func TestVoidOfCourseMoon(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+
+	// On 31 December 2024 at 07:03 UT the Moon in Capricorn is sextile
+	// Saturn, then void of course until it enters Aquarius on 1 January 2025
+	// at 10:50 UT.
+	for _, jdStart := range []float64{2460675.5, 2460676.5} {
+		last, change, err := swego.VoidOfCourseMoon(swe, jdStart, fl)
+		if err != nil {
+			t.Fatalf("VoidOfCourseMoon(%f) err = %q", jdStart, err)
+		}
+
+		if !inDelta(last, 2460675.7935, 1e-4) || !inDelta(change, 2460676.9511, 1e-4) {
+			t.Errorf("VoidOfCourseMoon(%f) = (%f, %f), want: (2460675.7935, 2460676.9511)",
+				jdStart, last, change)
+		}
+
+		moon, _, err := swe.CalcUT(change, swego.Moon, fl)
+		if err != nil {
+			t.Fatalf("CalcUT(%f) err = %q", change, err)
+		}
+
+		if !inDelta(moon[0], 300, 1e-6) {
+			t.Errorf("Moon at sign change = %f, want: 300", moon[0])
+		}
+	}
+}
+
+func TestNextStation(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+
+	// Mercury turns retrograde on 2020-02-17 at 0:54 UT and direct on
+	// 2020-03-10 at 3:49 UT.
+	cases := []struct {
+		start float64
+		want  float64
+		retro bool
+	}{
+		{2458849.5, 2458896.5375, true},
+		{2458897.5, 2458918.6590, false},
+	}
+
+	for _, c := range cases {
+		jd, retro, err := swego.NextStation(swe, c.start, swego.Mercury, fl)
+		if err != nil {
+			t.Fatalf("NextStation(%f) err = %q", c.start, err)
+		}
+
+		if !inDelta(jd, c.want, 2./1440) || retro != c.retro {
+			t.Errorf("NextStation(%f) = (%f, %t), want: (%f, %t)", c.start, jd, retro, c.want, c.retro)
+		}
+	}
+
+	for _, pl := range []swego.Planet{swego.Sun, swego.Moon, swego.MeanNode} {
+		if _, _, err := swego.NextStation(swe, 2458849.5, pl, fl); err != swego.ErrNoStation {
+			t.Errorf("NextStation(%d) err = %v, want: %v", pl, err, swego.ErrNoStation)
+		}
+	}
+}
+
+func TestNextAspect(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+
+	cases := []struct {
+		p1, p2 swego.Planet
+		aspect float64
+		want   float64
+	}{
+		{swego.Jupiter, swego.Saturn, 0, 2459205.2643}, // 2020-12-21 18:20 UT
+		{swego.Moon, swego.Sun, 180, 2458859.3065},     // 2020-01-10 19:21 UT
+		{swego.Moon, swego.Sun, 0, 2458873.4042},       // 2020-01-24 21:42 UT
+	}
+
+	for _, c := range cases {
+		jd, err := swego.NextAspect(swe, 2458849.5, c.p1, c.p2, c.aspect, fl)
+		if err != nil {
+			t.Fatalf("NextAspect(%d, %d, %g) err = %q", c.p1, c.p2, c.aspect, err)
+		}
+
+		if !inDelta(jd, c.want, 2./1440) {
+			t.Errorf("NextAspect(%d, %d, %g) = %f, want: %f", c.p1, c.p2, c.aspect, jd, c.want)
+		}
+	}
+
+	if _, err := swego.NextAspect(swe, 2458849.5, swego.Mars, swego.Mars, 10, fl); err != swego.ErrNoAspect {
+		t.Errorf("NextAspect(Mars, Mars) err = %v, want: %v", err, swego.ErrNoAspect)
+	}
+}
+
+func TestNextAntisciaContact(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+	jd, err := swego.NextAntisciaContact(swe, 2458849.5, swego.Moon, swego.Sun, fl)
+	if err != nil {
+		t.Fatalf("NextAntisciaContact() err = %q", err)
+	}
+
+	if want := 2458868.4925; !inDelta(jd, want, 2./1440) {
+		t.Errorf("NextAntisciaContact() = %f, want: %f", jd, want)
+	}
+
+	moon, _, _ := swe.CalcUT(jd, swego.Moon, fl)
+	sun, _, _ := swe.CalcUT(jd, swego.Sun, fl)
+	if anti := swego.Antiscion(moon[0]); !inDelta(anti, sun[0], 1e-5) {
+		t.Errorf("Antiscion(Moon) = %f, want: Sun %f", anti, sun[0])
+	}
+}
+
+func TestNextDeclinationParallel(t *testing.T) {
+	t.Parallel()
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+
+	dec, err := swego.Declination(swe, 2451545.0, swego.Sun, fl)
+	if err != nil || !inDelta(dec, -23.032484, 1e-6) {
+		t.Errorf("Declination(Sun) = (%f, %v), want: (-23.032484, nil)", dec, err)
+	}
+
+	for _, contra := range []bool{false, true} {
+		jd, err := swego.NextDeclinationParallel(swe, 2458849.5, swego.Moon, swego.Sun, contra, fl)
+		if err != nil {
+			t.Fatalf("NextDeclinationParallel(%t) err = %q", contra, err)
+		}
+
+		fle := &swego.CalcFlags{Flags: swego.FlagEphMoshier | swego.FlagEquatorial}
+		moon, _, _ := swe.CalcUT(jd, swego.Moon, fle)
+		sun, _, _ := swe.CalcUT(jd, swego.Sun, fle)
+
+		want := sun[1]
+		if contra {
+			want = -want
+		}
+
+		if !inDelta(moon[1], want, 1e-5) {
+			t.Errorf("NextDeclinationParallel(%t) = %f with Moon %f, want: %f", contra, jd, moon[1], want)
+		}
+	}
+}
+
+func TestOutOfBounds(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		et   float64
+		pl   swego.Planet
+		decl float64
+		oob  bool
+	}{
+		{2460578.25, swego.Moon, 28.70, true}, // 2024-09-25, near the major lunar standstill
+		{2457245, swego.Moon, 18.29, false},   // 2015-08-10, near the minor lunar standstill
+		{2451716.5, swego.Sun, 23.44, false},  // 2000-06-21, June solstice
+	}
+
+	fl := &swego.CalcFlags{Flags: swego.FlagEphMoshier}
+
+	for _, c := range cases {
+		decl, eps, oob, err := swego.OutOfBounds(swe, c.et, c.pl, fl)
+		if err != nil {
+			t.Fatalf("OutOfBounds(%f, %d) err = %q", c.et, c.pl, err)
+		}
+
+		if !inDelta(decl, c.decl, .01) || oob != c.oob || !inDelta(eps, 23.44, .01) {
+			t.Errorf("OutOfBounds(%f, %d) = (%f, %f, %t), want: (%f, 23.44, %t)",
+				c.et, c.pl, decl, eps, oob, c.decl, c.oob)
+		}
+	}
+}
+
+func TestComputeChart(t *testing.T) {
+	t.Parallel()
+
+	fl := swego.NewCalcFlags().Ephemeris(swego.Moshier).Speed().Sidereal(swego.SidmLahiri)
+	loc := &swego.GeoLoc{Long: 5.116667, Lat: 52.083333}
+	bodies := []swego.Planet{swego.Sun, swego.Moon, swego.Mars}
+
+	c, err := swego.ComputeChart(swe, 2451544.5, loc, swego.Placidus, bodies, fl)
+	if err != nil {
+		t.Fatalf("ComputeChart() err = %q", err)
+	}
+
+	for _, pl := range bodies {
+		xx, _, _ := swe.CalcUT(2451544.5, pl, fl)
+		if !inDeltaSlice(c.Positions[pl].XX, xx, 1e-9) {
+			t.Errorf("Positions[%d] = %v, want: %v", pl, c.Positions[pl].XX, xx)
+		}
+	}
+
+	hfl := &swego.HousesExFlags{Flags: swego.FlagSidereal, SidMode: fl.SidMode}
+	_, ascmc, _ := swe.HousesEx(2451544.5, hfl, loc, swego.Placidus)
+	if !inDelta(c.Houses.Ascendant, ascmc[swego.Asc], 1e-9) || len(c.Houses.Cusps) != 13 {
+		t.Errorf("Houses = %v, want Ascendant %f", c.Houses, ascmc[swego.Asc])
+	}
+
+	if !inDelta(c.Ayanamsa, 23.857, .01) {
+		t.Errorf("Ayanamsa = %f, want: about 23.857", c.Ayanamsa)
+	}
+}
@@ -3,6 +3,10 @@
 package swecgo
 
 import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
 	"unsafe"
 
 	"github.com/astrotools/swego"
@@ -72,17 +76,32 @@ func checkLibrary() {
 
 // withError calls fn with a pre allocated error variable that can passed to a
 // function in the C library. The code block must return true if an error is
-// returned from the C call.
+// returned from the C call. The C call is assumed to have returned ERR.
 func withError(fn func(err *C.char) bool) error {
 	var _err [C.AS_MAXCH]C.char
 
 	if fn(&_err[0]) {
-		return swego.Error(C.GoString(&_err[0]))
+		return swego.Error{Code: C.ERR, Message: C.GoString(&_err[0])}
 	}
 
 	return nil
 }
 
+// withWarning calls fn with a pre allocated error variable like withError. The
+// code block must return the return code of the C call. An error message
+// reported along with a non-negative return code is returned as warning, with
+// field Warning set.
+func withWarning(fn func(err *C.char) C.int32) (rc int, err error) {
+	var _err [C.AS_MAXCH]C.char
+
+	rc = int(fn(&_err[0]))
+	if rc == C.ERR || _err[0] != 0 {
+		err = swego.Error{Code: rc, Message: C.GoString(&_err[0]), Warning: rc != C.ERR}
+	}
+
+	return rc, err
+}
+
 // Swiss Ephemeris version constants.
 const (
 	Version      = C.SE_VERSION
@@ -94,6 +113,19 @@ const (
 // DefaultPath is the default ephemeris path defined by the library.
 const DefaultPath = C.SE_EPHE_PATH
 
+// Ephemeris file slots for GetCurrentFileData defined in sweph.h.
+const (
+	FilePlanet  = C.SEI_FILE_PLANET
+	FileMoon    = C.SEI_FILE_MOON
+	FileMainAst = C.SEI_FILE_MAIN_AST
+	FileAnyAst  = C.SEI_FILE_ANY_AST
+	FileFixStar = C.SEI_FILE_FIXSTAR
+)
+
+// ErrNoFileData is returned by GetCurrentFileData if no ephemeris file is
+// loaded in the requested slot.
+var ErrNoFileData = swego.Error{Code: C.ERR, Message: "no ephemeris file loaded"}
+
 const (
 	flgTopo     = C.SEFLG_TOPOCTR
 	flgSidereal = C.SEFLG_SIDEREAL
@@ -103,6 +135,7 @@ func setEphePath(path string) {
 	_path := C.CString(path)
 	C.swe_set_ephe_path(_path)
 	C.free(unsafe.Pointer(_path))
+	resetPlanetNames()
 }
 
 func setJPLFile(name string) {
@@ -111,6 +144,66 @@ func setJPLFile(name string) {
 	C.free(unsafe.Pointer(_name))
 }
 
+// checkEphemeris returns an error if the ephemeris selected by fl is not
+// available and the library would fall back to another ephemeris.
+func checkEphemeris(fl *swego.CalcFlags) error {
+	eph := fl.Flags & (C.SEFLG_JPLEPH | C.SEFLG_SWIEPH | C.SEFLG_MOSEPH)
+	if eph == C.SEFLG_MOSEPH {
+		return nil
+	}
+
+	if fl.JPLFile != "" {
+		setJPLFile(fl.JPLFile)
+	}
+
+	_, cfl, err := calc(2451545.0, swego.Sun, eph)
+	if cfl >= 0 && int32(cfl)&eph != 0 {
+		return nil
+	}
+
+	msg := "ephemeris not available"
+	if e, ok := err.(swego.Error); ok && e.Message != "" {
+		msg = e.Message
+	}
+
+	return swego.Error{Code: C.ERR, Message: msg}
+}
+
+// ephemerisFileStep is the step in days used to enumerate the ephemeris
+// files of a period, one century which is shorter than the period of a file.
+const ephemerisFileStep = 36524.25
+
+// checkEphemerisFiles returns the names of the planet, Moon and main asteroid
+// files covering the period fromJD to toJD that are not found in the
+// ephemeris path or that are not read by a trial calculation.
+func checkEphemerisFiles(fromJD, toJD float64) ([]string, error) {
+	if !(fromJD <= toJD) {
+		return nil, swego.Error{Code: C.ERR, Message: "invalid period: from after to"}
+	}
+
+	var missing []string
+	seen := make(map[string]bool)
+	check := func(jd float64) {
+		for _, ifno := range []int{FilePlanet, FileMoon, FileMainAst} {
+			var _fname [C.AS_MAXCH]C.char
+			found := bool(C.swex_find_ephe_file(C.double(jd), C.int(ifno), &_fname[0]))
+			fname := C.GoString(&_fname[0])
+			if !found && !seen[fname] {
+				missing = append(missing, fname)
+			}
+
+			seen[fname] = true
+		}
+	}
+
+	for jd := fromJD; jd < toJD; jd += ephemerisFileStep {
+		check(jd)
+	}
+
+	check(toJD)
+	return missing, nil
+}
+
 func setTopo(lng, lat, alt float64) {
 	C.swex_set_topo(C.double(lng), C.double(lat), C.double(alt))
 }
@@ -121,12 +214,100 @@ func setSidMode(mode swego.Ayanamsa, t0, ayanT0 float64) {
 
 func closeEphemeris() {
 	C.swe_close()
+	resetPlanetNames()
+}
+
+func setTidAcc(tacc float64) {
+	C.swe_set_tid_acc(C.double(tacc))
+}
+
+func getTidAcc() float64 {
+	return float64(C.swe_get_tid_acc())
+}
+
+func setLapseRate(lrate float64) {
+	C.swe_set_lapse_rate(C.double(lrate))
+}
+
+func setAstroModels(samod string, fl int32) {
+	_samod := C.CString(samod)
+	C.swe_set_astro_models(_samod, C.int32(fl))
+	C.free(unsafe.Pointer(_samod))
+}
+
+func getAstroModels() string {
+	var _samod [C.AS_MAXCH]C.char
+	C.swex_get_astro_models(&_samod[0])
+	return C.GoString(&_samod[0])
 }
 
+func sidTimeModel(ut float64, model int) float64 {
+	return float64(C.swex_sidtime_model(C.double(ut), C.int32_t(model)))
+}
+
+func getCurrentFileData(ifno int) (path string, tfstart, tfend float64, denum int, err error) {
+	if ifno < FilePlanet || ifno > FileFixStar {
+		return "", 0, 0, 0, swego.Error{Code: C.ERR, Message: "invalid file slot: " + strconv.Itoa(ifno)}
+	}
+
+	var _tfstart, _tfend C.double
+	var _denum C.int
+
+	_path := C.swex_get_current_file_data(C.int(ifno), &_tfstart, &_tfend, &_denum)
+	if _path == nil {
+		return "", 0, 0, 0, ErrNoFileData
+	}
+
+	return C.GoString(_path), float64(_tfstart), float64(_tfend), int(_denum), nil
+}
+
+// planetNames caches the names returned by swe_get_planet_name. It is
+// guarded by the library lock and cleared by closeEphemeris and setEphePath,
+// the names of the fictitious bodies are read from seorbel.txt in the
+// ephemeris path.
+var planetNames = make(map[swego.Planet]string)
+
+func resetPlanetNames() { planetNames = make(map[swego.Planet]string) }
+
 func planetName(pl swego.Planet) string {
+	if name, ok := planetNames[pl]; ok {
+		return name
+	}
+
 	var _name [C.AS_MAXCH]C.char
 	C.swe_get_planet_name(C.int(pl), &_name[0])
-	return C.GoString(&_name[0])
+	name := C.GoString(&_name[0])
+	planetNames[pl] = name
+	return name
+}
+
+// planetNumber returns the body with name name, matched case insensitively,
+// from the planets and the fictitious bodies.
+func planetNumber(name string) (swego.Planet, bool) {
+	for pl := swego.Planet(0); pl < C.SE_NPLANETS; pl++ {
+		if strings.EqualFold(planetName(pl), name) {
+			return pl, true
+		}
+	}
+
+	for pl := swego.Planet(C.SE_FICT_OFFSET); pl < C.SE_FICT_OFFSET+C.SE_NFICT_ELEM; pl++ {
+		if strings.EqualFold(planetName(pl), name) {
+			return pl, true
+		}
+	}
+
+	return 0, false
+}
+
+// cDoubles returns a pointer to the first element of xs that can be passed to
+// the C library as a double array. Both the float64 and C.double types are
+// defined as an IEEE-754 64-bit floating-point number. This means the
+// representation in memory of a float64 array is equivalent to that of a
+// C.double array. It means it is possible to cast between the two types. In Go
+// land such operation is considered unsafe, hence the use of the unsafe
+// package.
+func cDoubles(xs []float64) *C.double {
+	return (*C.double)(unsafe.Pointer(&xs[0]))
 }
 
 type _calcFunc func(jd C.double, fl C.int32, xx *C.double, err *C.char) C.int32
@@ -135,22 +316,56 @@ func _calc(jd float64, fl int32, fn _calcFunc) (_ []float64, cfl int, err error)
 	_jd := C.double(jd)
 	_fl := C.int32(fl)
 
-	// Both the float64 and C.double types are defined as an IEEE-754 64-bit
-	// floating-point number. This means the representation in memory of a
-	// float64 array is equivalent to that of a C.double array. It means it is
-	// possible to cast between the two types. In Go land such operation is
-	// considered unsafe, hence the use of the unsafe package.
 	var xx [6]float64
-	_xx := (*C.double)(unsafe.Pointer(&xx[0]))
+	_xx := cDoubles(xx[:])
 
-	err = withError(func(err *C.char) bool {
-		cfl = int(fn(_jd, _fl, _xx, err))
-		return cfl == C.ERR
+	cfl, err = withWarning(func(err *C.char) C.int32 {
+		return fn(_jd, _fl, _xx, err)
 	})
 
+	if cfl == C.ERR {
+		err = dateRangeError(err)
+	}
+
 	return xx[:], cfl, err
 }
 
+// dateRangeRegexp matches the error messages of the JPL, Moshier planet and
+// Moshier moon ephemerides for a date outside their range, for example:
+//
+//	jd 99999999.000000 outside JPL eph. range -3027215.50 .. 7930192.50;
+var dateRangeRegexp = regexp.MustCompile(`jd (-?[0-9.]+) outside (.+?) range (-?[0-9.]+) \.\. (-?[0-9.]+)`)
+
+// dateRangeError returns a *swego.DateRangeError if err is an error of the
+// library reporting a date outside the range of the ephemeris, otherwise err
+// is returned.
+func dateRangeError(err error) error {
+	e, ok := err.(swego.Error)
+	if !ok {
+		return err
+	}
+
+	m := dateRangeRegexp.FindStringSubmatch(e.Message)
+	if m == nil {
+		return err
+	}
+
+	jd, err1 := strconv.ParseFloat(m[1], 64)
+	start, err2 := strconv.ParseFloat(m[3], 64)
+	end, err3 := strconv.ParseFloat(m[4], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return err
+	}
+
+	return &swego.DateRangeError{
+		JD:        jd,
+		Start:     start,
+		End:       end,
+		Ephemeris: m[2],
+		Message:   e.Message,
+	}
+}
+
 func calc(et float64, pl swego.Planet, fl int32) ([]float64, int, error) {
 	return _calc(et, fl, func(jd C.double, fl C.int32, xx *C.double, err *C.char) C.int32 {
 		return C.swe_calc(jd, C.int(pl), fl, xx, err)
@@ -163,6 +378,81 @@ func calcUT(ut float64, pl swego.Planet, fl int32) ([]float64, int, error) {
 	})
 }
 
+func calcPctr(et float64, pl, plctr swego.Planet, fl int32) ([]float64, int, error) {
+	return _calc(et, fl, func(jd C.double, fl C.int32, xx *C.double, err *C.char) C.int32 {
+		return C.swex_calc_pctr(jd, C.int32(pl), C.int32(plctr), fl, xx, err)
+	})
+}
+
+// starBuffer copies the star name to a buffer that can be passed to the fixed
+// star functions of the C library. These functions write the traditional name
+// and nomenclature name of the star found to the input buffer. The buffer is
+// twice the size of the maximum star name length to make room for the
+// returned star name.
+func starBuffer(star string) (buf [2 * C.SE_MAX_STNAME]C.char) {
+	for i := 0; i < len(star) && i < C.SE_MAX_STNAME; i++ {
+		buf[i] = C.char(star[i])
+	}
+
+	return
+}
+
+type _fixStarFunc func(star *C.char, jd C.double, fl C.int32, xx *C.double, err *C.char) C.int32
+
+func _fixStar(star string, jd float64, fl int32, fn _fixStarFunc) (name string, _ []float64, cfl int, err error) {
+	_star := starBuffer(star)
+	_jd := C.double(jd)
+	_fl := C.int32(fl)
+
+	var xx [6]float64
+	_xx := cDoubles(xx[:])
+
+	cfl, err = withWarning(func(err *C.char) C.int32 {
+		return fn(&_star[0], _jd, _fl, _xx, err)
+	})
+
+	if cfl == C.ERR {
+		err = dateRangeError(err)
+	}
+
+	return C.GoString(&_star[0]), xx[:], cfl, err
+}
+
+func fixStar(star string, et float64, fl int32) (string, []float64, int, error) {
+	return _fixStar(star, et, fl, func(star *C.char, jd C.double, fl C.int32, xx *C.double, err *C.char) C.int32 {
+		return C.swe_fixstar(star, jd, fl, xx, err)
+	})
+}
+
+func fixStarUT(star string, ut float64, fl int32) (string, []float64, int, error) {
+	return _fixStar(star, ut, fl, func(star *C.char, jd C.double, fl C.int32, xx *C.double, err *C.char) C.int32 {
+		return C.swe_fixstar_ut(star, jd, fl, xx, err)
+	})
+}
+
+func fixStar2(star string, et float64, fl int32) (string, []float64, int, error) {
+	return _fixStar(star, et, fl, func(star *C.char, jd C.double, fl C.int32, xx *C.double, err *C.char) C.int32 {
+		return C.swex_fixstar2(star, jd, fl, xx, err)
+	})
+}
+
+func fixStar2UT(star string, ut float64, fl int32) (string, []float64, int, error) {
+	return _fixStar(star, ut, fl, func(star *C.char, jd C.double, fl C.int32, xx *C.double, err *C.char) C.int32 {
+		return C.swex_fixstar2_ut(star, jd, fl, xx, err)
+	})
+}
+
+func fixStarMag(star string) (name string, mag float64, err error) {
+	_star := starBuffer(star)
+	var _mag C.double
+
+	err = withError(func(err *C.char) bool {
+		return C.ERR == C.swe_fixstar_mag(&_star[0], &_mag, err)
+	})
+
+	return C.GoString(&_star[0]), float64(_mag), err
+}
+
 type _nodApsFunc func(jd C.double, pl, fl, m C.int32, nasc, ndsc, peri, aphe *C.double, err *C.char) C.int32
 
 func _nodAps(jd float64, pl swego.Planet, fl int32, m swego.NodApsMethod, fn _nodApsFunc) (_, _, _, _ []float64, err error) {
@@ -171,20 +461,15 @@ func _nodAps(jd float64, pl swego.Planet, fl int32, m swego.NodApsMethod, fn _no
 	_fl := C.int32(fl)
 	_m := C.int32(m)
 
-	// Both the float64 and C.double types are defined as an IEEE-754 64-bit
-	// floating-point number. This means the representation in memory of a
-	// float64 array is equivalent to that of a C.double array. It means it is
-	// possible to cast between the two types. In Go land such operation is
-	// considered unsafe, hence the use of the unsafe package.
 	var nasc, ndsc, peri, aphe [6]float64
-	_nasc := (*C.double)(unsafe.Pointer(&nasc[0]))
-	_ndsc := (*C.double)(unsafe.Pointer(&ndsc[0]))
-	_peri := (*C.double)(unsafe.Pointer(&peri[0]))
-	_aphe := (*C.double)(unsafe.Pointer(&aphe[0]))
+	_nasc := cDoubles(nasc[:])
+	_ndsc := cDoubles(ndsc[:])
+	_peri := cDoubles(peri[:])
+	_aphe := cDoubles(aphe[:])
 
-	err = withError(func(err *C.char) bool {
+	err = dateRangeError(withError(func(err *C.char) bool {
 		return C.ERR == fn(_jd, _pl, _fl, _m, _nasc, _ndsc, _peri, _aphe, err)
-	})
+	}))
 
 	return nasc[:], ndsc[:], peri[:], aphe[:], err
 }
@@ -201,6 +486,145 @@ func nodApsUT(ut float64, pl swego.Planet, fl int32, m swego.NodApsMethod) (nasc
 	})
 }
 
+type _phenoFunc func(jd C.double, pl, fl C.int32, attr *C.double, err *C.char) C.int32
+
+func _pheno(jd float64, pl swego.Planet, fl int32, fn _phenoFunc) (_ []float64, err error) {
+	_jd := C.double(jd)
+	_pl := C.int32(pl)
+	_fl := C.int32(fl)
+
+	var attr [20]float64
+	_attr := cDoubles(attr[:])
+
+	err = dateRangeError(withError(func(err *C.char) bool {
+		return C.ERR == fn(_jd, _pl, _fl, _attr, err)
+	}))
+
+	return attr[:], err
+}
+
+func pheno(et float64, pl swego.Planet, fl int32) ([]float64, error) {
+	return _pheno(et, pl, fl, func(jd C.double, pl, fl C.int32, attr *C.double, err *C.char) C.int32 {
+		return C.swe_pheno(jd, pl, fl, attr, err)
+	})
+}
+
+func phenoUT(ut float64, pl swego.Planet, fl int32) ([]float64, error) {
+	return _pheno(ut, pl, fl, func(jd C.double, pl, fl C.int32, attr *C.double, err *C.char) C.int32 {
+		return C.swe_pheno_ut(jd, pl, fl, attr, err)
+	})
+}
+
+func orbitalElements(et float64, pl swego.Planet, fl int32) (_ []float64, err error) {
+	_et := C.double(et)
+	_pl := C.int32(pl)
+	_fl := C.int32(fl)
+
+	var dret [50]float64
+	_dret := cDoubles(dret[:])
+
+	err = dateRangeError(withError(func(err *C.char) bool {
+		return C.ERR == C.swe_get_orbital_elements(_et, _pl, _fl, _dret, err)
+	}))
+
+	return dret[:], err
+}
+
+func orbitMaxMinTrueDistance(et float64, pl swego.Planet, fl int32) (dmax, dmin, dtrue float64, err error) {
+	_et := C.double(et)
+	_pl := C.int32(pl)
+	_fl := C.int32(fl)
+	var _dmax, _dmin, _dtrue C.double
+
+	err = dateRangeError(withError(func(err *C.char) bool {
+		return C.ERR == C.swe_orbit_max_min_true_distance(_et, _pl, _fl, &_dmax, &_dmin, &_dtrue, err)
+	}))
+
+	return float64(_dmax), float64(_dmin), float64(_dtrue), err
+}
+
+type _crossFunc func(x2cross, jd C.double, fl, dir C.int32_t, jdCross *C.double, err *C.char) C.int32_t
+
+func _cross(x2cross, jd float64, fl int32, backward bool, fn _crossFunc) (jdCross float64, err error) {
+	_x2cross := C.double(x2cross)
+	_jd := C.double(jd)
+	_fl := C.int32_t(fl)
+	_dir := C.int32_t(1)
+	if backward {
+		_dir = -1
+	}
+
+	var _jdCross C.double
+
+	err = dateRangeError(withError(func(err *C.char) bool {
+		return C.ERR == fn(_x2cross, _jd, _fl, _dir, &_jdCross, err)
+	}))
+
+	return float64(_jdCross), err
+}
+
+func solCross(x2cross, et float64, fl int32, backward bool) (float64, error) {
+	return _cross(x2cross, et, fl, backward, func(x2cross, jd C.double, fl, dir C.int32_t, jdCross *C.double, err *C.char) C.int32_t {
+		return C.swex_solcross(x2cross, jd, fl, dir, jdCross, err)
+	})
+}
+
+func solCrossUT(x2cross, ut float64, fl int32, backward bool) (float64, error) {
+	return _cross(x2cross, ut, fl, backward, func(x2cross, jd C.double, fl, dir C.int32_t, jdCross *C.double, err *C.char) C.int32_t {
+		return C.swex_solcross_ut(x2cross, jd, fl, dir, jdCross, err)
+	})
+}
+
+func moonCross(x2cross, et float64, fl int32) (float64, error) {
+	return _cross(x2cross, et, fl, false, func(x2cross, jd C.double, fl, _ C.int32_t, jdCross *C.double, err *C.char) C.int32_t {
+		return C.swex_mooncross(x2cross, jd, fl, jdCross, err)
+	})
+}
+
+func moonCrossUT(x2cross, ut float64, fl int32) (float64, error) {
+	return _cross(x2cross, ut, fl, false, func(x2cross, jd C.double, fl, _ C.int32_t, jdCross *C.double, err *C.char) C.int32_t {
+		return C.swex_mooncross_ut(x2cross, jd, fl, jdCross, err)
+	})
+}
+
+func helioCross(pl swego.Planet, x2cross, et float64, fl int32, backward bool) (float64, error) {
+	return _cross(x2cross, et, fl, backward, func(x2cross, jd C.double, fl, dir C.int32_t, jdCross *C.double, err *C.char) C.int32_t {
+		return C.swex_helio_cross(C.int32_t(pl), x2cross, jd, fl, dir, jdCross, err)
+	})
+}
+
+func helioCrossUT(pl swego.Planet, x2cross, ut float64, fl int32, backward bool) (float64, error) {
+	return _cross(x2cross, ut, fl, backward, func(x2cross, jd C.double, fl, dir C.int32_t, jdCross *C.double, err *C.char) C.int32_t {
+		return C.swex_helio_cross_ut(C.int32_t(pl), x2cross, jd, fl, dir, jdCross, err)
+	})
+}
+
+type _moonCrossNodeFunc func(jd C.double, fl C.int32_t, jdCross, xlon, xlat *C.double, err *C.char) C.int32_t
+
+func _moonCrossNode(jd float64, fl int32, fn _moonCrossNodeFunc) (jdCross, xlon, xlat float64, err error) {
+	_jd := C.double(jd)
+	_fl := C.int32_t(fl)
+	var _jdCross, _xlon, _xlat C.double
+
+	err = dateRangeError(withError(func(err *C.char) bool {
+		return C.ERR == fn(_jd, _fl, &_jdCross, &_xlon, &_xlat, err)
+	}))
+
+	return float64(_jdCross), float64(_xlon), float64(_xlat), err
+}
+
+func moonCrossNode(et float64, fl int32) (float64, float64, float64, error) {
+	return _moonCrossNode(et, fl, func(jd C.double, fl C.int32_t, jdCross, xlon, xlat *C.double, err *C.char) C.int32_t {
+		return C.swex_mooncross_node(jd, fl, jdCross, xlon, xlat, err)
+	})
+}
+
+func moonCrossNodeUT(ut float64, fl int32) (float64, float64, float64, error) {
+	return _moonCrossNode(ut, fl, func(jd C.double, fl C.int32_t, jdCross, xlon, xlat *C.double, err *C.char) C.int32_t {
+		return C.swex_mooncross_node_ut(jd, fl, jdCross, xlon, xlat, err)
+	})
+}
+
 type _getAyanamsaExFunc func(jd C.double, fl C.int32, aya *C.double, err *C.char) C.int32
 
 func _getAyanamsaEx(jd float64, fl int32, fn _getAyanamsaExFunc) (aya float64, err error) {
@@ -240,6 +664,24 @@ func julDay(y, m, d int, h float64, gf int) float64 {
 	return float64(C.swe_julday(_y, _m, _d, _h, _gf))
 }
 
+func dateConversion(y, m, d int, h float64, ct swego.CalType) (float64, error) {
+	_y := C.int(y)
+	_m := C.int(m)
+	_d := C.int(d)
+	_h := C.double(h)
+	_c := C.char('j')
+	if ct == swego.Gregorian {
+		_c = 'g'
+	}
+
+	var _jd C.double
+	if C.ERR == C.swe_date_conversion(_y, _m, _d, _h, _c, &_jd) {
+		return float64(_jd), swego.ErrInvalidDate
+	}
+
+	return float64(_jd), nil
+}
+
 func revJul(jd float64, gf int) (y, m, d int, h float64) {
 	_jd := C.double(jd)
 	_gf := C.int(gf)
@@ -305,24 +747,31 @@ func jdUT1ToUTC(ut float64, gf int) (y, m, d, h, i int, s float64) {
 	})
 }
 
+func dayOfWeek(jd float64) int {
+	return int(C.swe_day_of_week(C.double(jd)))
+}
+
 type _housesFunc func(lat C.double, hsys C.int, cusps, ascmc *C.double) C.int
 
 func _houses(lat float64, hsys swego.HSys, fn _housesFunc) (_, _ []float64, err error) {
 	_lat := C.double(lat)
 	_hsys := C.int(hsys)
 
-	// Both the float64 and C.double types are defined as an IEEE-754 64-bit
-	// floating-point number. This means the representation in memory of a
-	// float64 array is equivalent to that of a C.double array. It means it is
-	// possible to cast between the two types. In Go land such operation is
-	// considered unsafe, hence the use of the unsafe package.
 	var cusps [37]float64
 	var ascmc [10]float64
-	_cusps := (*C.double)(unsafe.Pointer(&cusps[0]))
-	_ascmc := (*C.double)(unsafe.Pointer(&ascmc[0]))
+	_cusps := cDoubles(cusps[:])
+	_ascmc := cDoubles(ascmc[:])
 
+	// The house functions return ERR if they fall back to Porphyry houses for
+	// a house system that is not defined at the latitude, but also if the
+	// Sunshine houses cannot compute the Sun. In the latter case the cusps are
+	// left untouched.
 	if C.ERR == fn(_lat, _hsys, _cusps, _ascmc) {
-		err = swego.Error("")
+		if sys, _ := swego.NewHSys(byte(hsys)); sys != swego.Porphyrius && housesFilled(cusps[:13]) {
+			err = swego.ErrHouseFallback
+		} else {
+			err = swego.Error{Code: C.ERR, Message: "house cusps not computed"}
+		}
 	}
 
 	// The house system letters are practically constants. If those are changed,
@@ -337,6 +786,17 @@ func _houses(lat float64, hsys swego.HSys, fn _housesFunc) (_, _ []float64, err
 	return cusps[:n:n], ascmc[:], err
 }
 
+// housesFilled reports whether any of the house cusps is set.
+func housesFilled(cusps []float64) bool {
+	for _, c := range cusps[1:] {
+		if c != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 func housesEx(ut float64, fl int32, lat, lng float64, hsys swego.HSys) ([]float64, []float64, error) {
 	return _houses(lat, hsys, func(lat C.double, hsys C.int, cusps, ascmc *C.double) C.int {
 		_jd := C.double(ut)
@@ -355,6 +815,10 @@ func housesARMC(armc, lat, eps float64, hsys swego.HSys) ([]float64, []float64,
 }
 
 func housePos(armc, geolat, eps float64, hsys swego.HSys, pllng, pllat float64) (pos float64, err error) {
+	if !hsys.Valid() {
+		return 0, swego.Error{Code: C.ERR, Message: "invalid house system: " + hsys.String()}
+	}
+
 	_armc := C.double(armc)
 	_lat := C.double(geolat)
 	_eps := C.double(eps)
@@ -366,6 +830,25 @@ func housePos(armc, geolat, eps float64, hsys swego.HSys, pllng, pllat float64)
 		return *err != '\000'
 	})
 
+	if err != nil {
+		return
+	}
+
+	// rounding may push the position just outside of the valid range
+	n := 12.0
+	if sys, _ := swego.NewHSys(byte(hsys)); sys == swego.Gauquelin {
+		n = 36
+	}
+
+	pos = 1 + math.Mod(pos-1, n)
+	if pos < 1 {
+		pos += n
+	}
+
+	if pos >= n+1 {
+		pos = 1
+	}
+
 	return
 }
 
@@ -373,10 +856,31 @@ func houseName(hsys swego.HSys) string {
 	return C.GoString(C.swe_house_name(C.int(hsys)))
 }
 
-func deltaTEx(jd float64, eph int32) (deltaT float64, err error) {
+func gauquelinSector(ut float64, pl swego.Planet, star string, fl int32, m swego.GauquelinMethod, geo swego.GeoLoc, press, temp float64) (pos float64, err error) {
+	_ut := C.double(ut)
+	_pl := C.int32(pl)
+	_star := starBuffer(star)
+	_fl := C.int32(fl)
+	_m := C.int32(m)
+	_geo := [3]C.double{C.double(geo.Long), C.double(geo.Lat), C.double(geo.Alt)}
+	_press := C.double(press)
+	_temp := C.double(temp)
+	var _pos C.double
+
 	err = withError(func(err *C.char) bool {
+		return C.ERR == C.swe_gauquelin_sector(_ut, _pl, &_star[0], _fl, _m, &_geo[0], _press, _temp, &_pos, err)
+	})
+
+	return float64(_pos), err
+}
+
+// deltaTEx returns the message of swe_deltat_ex as warning, the function has
+// no return code and reports only the fallback to another ephemeris, for which
+// the returned ΔT is valid.
+func deltaTEx(jd float64, eph int32) (deltaT float64, err error) {
+	_, err = withWarning(func(err *C.char) C.int32 {
 		deltaT = float64(C.swe_deltat_ex(C.double(jd), C.int32(eph), err))
-		return *err != '\000'
+		return 0
 	})
 
 	return
@@ -434,3 +938,385 @@ func sidTime0(ut, eps, nut float64) float64 {
 func sidTime(ut float64) float64 {
 	return float64(C.swe_sidtime(C.double(ut)))
 }
+
+func azalt(ut float64, m swego.AzaltMode, geo swego.GeoLoc, press, temp float64, in [3]float64) []float64 {
+	_ut := C.double(ut)
+	_m := C.int32(m)
+	_geo := [3]C.double{C.double(geo.Long), C.double(geo.Lat), C.double(geo.Alt)}
+	_press := C.double(press)
+	_temp := C.double(temp)
+	_in := [3]C.double{C.double(in[0]), C.double(in[1]), C.double(in[2])}
+
+	var out [3]float64
+	_out := cDoubles(out[:])
+
+	C.swe_azalt(_ut, _m, &_geo[0], _press, _temp, &_in[0], _out)
+	return out[:]
+}
+
+func azaltRev(ut float64, m swego.AzaltRevMode, geo swego.GeoLoc, in [2]float64) []float64 {
+	_ut := C.double(ut)
+	_m := C.int32(m)
+	_geo := [3]C.double{C.double(geo.Long), C.double(geo.Lat), C.double(geo.Alt)}
+	_in := [2]C.double{C.double(in[0]), C.double(in[1])}
+
+	var out [2]float64
+	_out := cDoubles(out[:])
+
+	C.swe_azalt_rev(_ut, _m, &_geo[0], &_in[0], _out)
+	return out[:]
+}
+
+func refrac(alt, press, temp float64, m swego.RefracMode) float64 {
+	return float64(C.swe_refrac(C.double(alt), C.double(press), C.double(temp), C.int32(m)))
+}
+
+func refracExtended(alt, geoalt, press, temp, lapseRate float64, m swego.RefracMode) (float64, []float64) {
+	_alt := C.double(alt)
+	_geoalt := C.double(geoalt)
+	_press := C.double(press)
+	_temp := C.double(temp)
+	_lapseRate := C.double(lapseRate)
+	_m := C.int32(m)
+
+	var dret [4]float64
+	_dret := cDoubles(dret[:])
+
+	f := C.swe_refrac_extended(_alt, _geoalt, _press, _temp, _lapseRate, _m, _dret)
+	return float64(f), dret[:]
+}
+
+type _riseTransFunc func(jd C.double, pl C.int32, star *C.char, fl, m C.int32, geo *C.double, press, temp C.double, tret *C.double, err *C.char) C.int32
+
+func _riseTrans(ut float64, pl swego.Planet, star string, fl int32, m swego.RiseTransMode, geo swego.GeoLoc, press, temp float64, fn _riseTransFunc) (tret float64, err error) {
+	_ut := C.double(ut)
+	_pl := C.int32(pl)
+	_star := starBuffer(star)
+	_fl := C.int32(fl)
+	_m := C.int32(m)
+	_geo := [3]C.double{C.double(geo.Long), C.double(geo.Lat), C.double(geo.Alt)}
+	_press := C.double(press)
+	_temp := C.double(temp)
+	var _tret C.double
+
+	var rc C.int32
+	err = withError(func(err *C.char) bool {
+		rc = fn(_ut, _pl, &_star[0], _fl, _m, &_geo[0], _press, _temp, &_tret, err)
+		return rc == C.ERR
+	})
+
+	if rc == -2 {
+		err = swego.ErrNoRiseSet
+	}
+
+	return float64(_tret), err
+}
+
+func riseTrans(ut float64, pl swego.Planet, star string, fl int32, m swego.RiseTransMode, geo swego.GeoLoc, press, temp float64) (float64, error) {
+	return _riseTrans(ut, pl, star, fl, m, geo, press, temp, func(jd C.double, pl C.int32, star *C.char, fl, m C.int32, geo *C.double, press, temp C.double, tret *C.double, err *C.char) C.int32 {
+		return C.swe_rise_trans(jd, pl, star, fl, m, geo, press, temp, tret, err)
+	})
+}
+
+func riseTransTrueHor(ut float64, pl swego.Planet, star string, fl int32, m swego.RiseTransMode, geo swego.GeoLoc, press, temp, horhgt float64) (float64, error) {
+	_horhgt := C.double(horhgt)
+	return _riseTrans(ut, pl, star, fl, m, geo, press, temp, func(jd C.double, pl C.int32, star *C.char, fl, m C.int32, geo *C.double, press, temp C.double, tret *C.double, err *C.char) C.int32 {
+		return C.swe_rise_trans_true_hor(jd, pl, star, fl, m, geo, press, temp, _horhgt, tret, err)
+	})
+}
+
+type _cotransFunc func(in, out *C.double, eps C.double)
+
+func _cotrans(in, out []float64, eps float64, fn _cotransFunc) {
+	_in := cDoubles(in)
+	_out := cDoubles(out)
+
+	fn(_in, _out, C.double(eps))
+}
+
+func cotrans(in [3]float64, eps float64) []float64 {
+	var out [3]float64
+	_cotrans(in[:], out[:], eps, func(in, out *C.double, eps C.double) {
+		C.swe_cotrans(in, out, eps)
+	})
+
+	return out[:]
+}
+
+func cotransSp(in [6]float64, eps float64) []float64 {
+	var out [6]float64
+	_cotrans(in[:], out[:], eps, func(in, out *C.double, eps C.double) {
+		C.swe_cotrans_sp(in, out, eps)
+	})
+
+	return out[:]
+}
+
+func degNorm(x float64) float64 {
+	return float64(C.swe_degnorm(C.double(x)))
+}
+
+func radNorm(x float64) float64 {
+	return float64(C.swe_radnorm(C.double(x)))
+}
+
+func degMidp(x1, x0 float64) float64 {
+	return float64(C.swe_deg_midp(C.double(x1), C.double(x0)))
+}
+
+func radMidp(x1, x0 float64) float64 {
+	return float64(C.swe_rad_midp(C.double(x1), C.double(x0)))
+}
+
+func splitDeg(x float64, fl swego.SplitDegFlags) (deg, min, sec int, secfr float64, sign int) {
+	var _deg, _min, _sec, _sign C.int32
+	var _secfr C.double
+	C.swe_split_deg(C.double(x), C.int32(fl), &_deg, &_min, &_sec, &_secfr, &_sign)
+	return int(_deg), int(_min), int(_sec), float64(_secfr), int(_sign)
+}
+
+func heliacalParams(geo swego.GeoLoc, atm swego.Atmosphere, obs swego.Observer) (_geo [3]C.double, _atm [4]C.double, _obs [6]C.double) {
+	_geo = [3]C.double{C.double(geo.Long), C.double(geo.Lat), C.double(geo.Alt)}
+	_atm = [4]C.double{C.double(atm.Press), C.double(atm.Temp), C.double(atm.RelHumidity), C.double(atm.Extinction)}
+	_obs = [6]C.double{C.double(obs.Age), C.double(obs.SnellenRatio), 0, C.double(obs.OpticMagn), C.double(obs.OpticDia), C.double(obs.OpticTrans)}
+
+	if obs.Binocular {
+		_obs[2] = 1
+	}
+
+	return
+}
+
+type _heliacalFunc func(jd C.double, geo, atm, obs *C.double, obj *C.char, ev, fl C.int32, dret *C.double, err *C.char) C.int32
+
+func _heliacal(jd float64, geo swego.GeoLoc, atm swego.Atmosphere, obs swego.Observer, obj string, ev swego.HeliacalEvent, fl int32, fn _heliacalFunc) (_ []float64, err error) {
+	_jd := C.double(jd)
+	_geo, _atm, _obs := heliacalParams(geo, atm, obs)
+	_obj := starBuffer(obj)
+	_ev := C.int32(ev)
+	_fl := C.int32(fl)
+
+	var dret [50]float64
+	_dret := cDoubles(dret[:])
+
+	err = withError(func(err *C.char) bool {
+		return C.ERR == fn(_jd, &_geo[0], &_atm[0], &_obs[0], &_obj[0], _ev, _fl, _dret, err)
+	})
+
+	return dret[:], err
+}
+
+func heliacalUT(ut float64, geo swego.GeoLoc, atm swego.Atmosphere, obs swego.Observer, obj string, ev swego.HeliacalEvent, fl int32) ([]float64, error) {
+	return _heliacal(ut, geo, atm, obs, obj, ev, fl, func(jd C.double, geo, atm, obs *C.double, obj *C.char, ev, fl C.int32, dret *C.double, err *C.char) C.int32 {
+		return C.swe_heliacal_ut(jd, geo, atm, obs, obj, ev, fl, dret, err)
+	})
+}
+
+func heliacalPhenoUT(ut float64, geo swego.GeoLoc, atm swego.Atmosphere, obs swego.Observer, obj string, ev swego.HeliacalEvent, fl int32) ([]float64, error) {
+	return _heliacal(ut, geo, atm, obs, obj, ev, fl, func(jd C.double, geo, atm, obs *C.double, obj *C.char, ev, fl C.int32, darr *C.double, err *C.char) C.int32 {
+		return C.swe_heliacal_pheno_ut(jd, geo, atm, obs, obj, ev, fl, darr, err)
+	})
+}
+
+func visLimitMag(ut float64, geo swego.GeoLoc, atm swego.Atmosphere, obs swego.Observer, obj string, fl int32) (_ []float64, visible bool, err error) {
+	_ut := C.double(ut)
+	_geo, _atm, _obs := heliacalParams(geo, atm, obs)
+	_obj := starBuffer(obj)
+	_fl := C.int32(fl)
+
+	var dret [8]float64
+	_dret := cDoubles(dret[:])
+
+	var rc C.int32
+	err = withError(func(err *C.char) bool {
+		rc = C.swe_vis_limit_mag(_ut, &_geo[0], &_atm[0], &_obs[0], &_obj[0], _fl, _dret, err)
+		return rc == C.ERR
+	})
+
+	return dret[:], rc >= 0, err
+}
+
+func solEclipseWhere(ut float64, fl int32) (_, _ []float64, typ swego.EclType, err error) {
+	_ut := C.double(ut)
+	_fl := C.int32(fl)
+
+	var geo [10]float64
+	var attr [20]float64
+	_geo := cDoubles(geo[:])
+	_attr := cDoubles(attr[:])
+
+	err = withError(func(err *C.char) bool {
+		typ = swego.EclType(C.swe_sol_eclipse_where(_ut, _fl, _geo, _attr, err))
+		return typ == C.ERR
+	})
+
+	return geo[:], attr[:], typ, err
+}
+
+func solEclipseHow(ut float64, fl int32, geo swego.GeoLoc) (_ []float64, typ swego.EclType, err error) {
+	_ut := C.double(ut)
+	_fl := C.int32(fl)
+	_geo := [3]C.double{C.double(geo.Long), C.double(geo.Lat), C.double(geo.Alt)}
+
+	var attr [20]float64
+	_attr := cDoubles(attr[:])
+
+	err = withError(func(err *C.char) bool {
+		typ = swego.EclType(C.swe_sol_eclipse_how(_ut, _fl, &_geo[0], _attr, err))
+		return typ == C.ERR
+	})
+
+	return attr[:], typ, err
+}
+
+func cbool(b bool) C.int32 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+type _eclipseWhenGlobFunc func(jd C.double, fl, typ C.int32, tret *C.double, backward C.int32, err *C.char) C.int32
+
+func _eclipseWhenGlob(jd float64, fl int32, typ swego.EclType, backward bool, fn _eclipseWhenGlobFunc) (_ []float64, rtyp swego.EclType, err error) {
+	_jd := C.double(jd)
+	_fl := C.int32(fl)
+	_typ := C.int32(typ)
+	_backward := cbool(backward)
+
+	var tret [10]float64
+	_tret := cDoubles(tret[:])
+
+	err = withError(func(err *C.char) bool {
+		rtyp = swego.EclType(fn(_jd, _fl, _typ, _tret, _backward, err))
+		return rtyp == C.ERR
+	})
+
+	return tret[:], rtyp, err
+}
+
+func solEclipseWhenGlob(ut float64, fl int32, typ swego.EclType, backward bool) ([]float64, swego.EclType, error) {
+	return _eclipseWhenGlob(ut, fl, typ, backward, func(jd C.double, fl, typ C.int32, tret *C.double, backward C.int32, err *C.char) C.int32 {
+		return C.swe_sol_eclipse_when_glob(jd, fl, typ, tret, backward, err)
+	})
+}
+
+type _eclipseWhenLocFunc func(jd C.double, fl C.int32, geo, tret, attr *C.double, backward C.int32, err *C.char) C.int32
+
+func _eclipseWhenLoc(jd float64, fl int32, geo swego.GeoLoc, backward bool, fn _eclipseWhenLocFunc) (_, _ []float64, typ swego.EclType, err error) {
+	_jd := C.double(jd)
+	_fl := C.int32(fl)
+	_geo := [3]C.double{C.double(geo.Long), C.double(geo.Lat), C.double(geo.Alt)}
+	_backward := cbool(backward)
+
+	var tret [10]float64
+	var attr [20]float64
+	_tret := cDoubles(tret[:])
+	_attr := cDoubles(attr[:])
+
+	err = withError(func(err *C.char) bool {
+		typ = swego.EclType(fn(_jd, _fl, &_geo[0], _tret, _attr, _backward, err))
+		return typ == C.ERR
+	})
+
+	return tret[:], attr[:], typ, err
+}
+
+func solEclipseWhenLoc(ut float64, fl int32, geo swego.GeoLoc, backward bool) ([]float64, []float64, swego.EclType, error) {
+	return _eclipseWhenLoc(ut, fl, geo, backward, func(jd C.double, fl C.int32, geo, tret, attr *C.double, backward C.int32, err *C.char) C.int32 {
+		return C.swe_sol_eclipse_when_loc(jd, fl, geo, tret, attr, backward, err)
+	})
+}
+
+func lunEclipseHow(ut float64, fl int32, geo swego.GeoLoc) (_ []float64, typ swego.EclType, err error) {
+	_ut := C.double(ut)
+	_fl := C.int32(fl)
+	_geo := [3]C.double{C.double(geo.Long), C.double(geo.Lat), C.double(geo.Alt)}
+
+	var attr [20]float64
+	_attr := cDoubles(attr[:])
+
+	err = withError(func(err *C.char) bool {
+		typ = swego.EclType(C.swe_lun_eclipse_how(_ut, _fl, &_geo[0], _attr, err))
+		return typ == C.ERR
+	})
+
+	return attr[:], typ, err
+}
+
+func lunEclipseWhen(ut float64, fl int32, typ swego.EclType, backward bool) ([]float64, swego.EclType, error) {
+	return _eclipseWhenGlob(ut, fl, typ, backward, func(jd C.double, fl, typ C.int32, tret *C.double, backward C.int32, err *C.char) C.int32 {
+		return C.swe_lun_eclipse_when(jd, fl, typ, tret, backward, err)
+	})
+}
+
+func lunEclipseWhenLoc(ut float64, fl int32, geo swego.GeoLoc, backward bool) ([]float64, []float64, swego.EclType, error) {
+	return _eclipseWhenLoc(ut, fl, geo, backward, func(jd C.double, fl C.int32, geo, tret, attr *C.double, backward C.int32, err *C.char) C.int32 {
+		return C.swe_lun_eclipse_when_loc(jd, fl, geo, tret, attr, backward, err)
+	})
+}
+
+func lunOccultWhere(ut float64, pl swego.Planet, star string, fl int32) (_, _ []float64, typ swego.EclType, err error) {
+	_ut := C.double(ut)
+	_pl := C.int32(pl)
+	_star := starBuffer(star)
+	_fl := C.int32(fl)
+
+	var geo [10]float64
+	var attr [20]float64
+	_geo := cDoubles(geo[:])
+	_attr := cDoubles(attr[:])
+
+	err = withError(func(err *C.char) bool {
+		typ = swego.EclType(C.swe_lun_occult_where(_ut, _pl, &_star[0], _fl, _geo, _attr, err))
+		return typ == C.ERR
+	})
+
+	return geo[:], attr[:], typ, err
+}
+
+func lunOccultWhenGlob(ut float64, pl swego.Planet, star string, fl int32, typ swego.EclType, backward bool) (_ []float64, rtyp swego.EclType, err error) {
+	_ut := C.double(ut)
+	_pl := C.int32(pl)
+	_star := starBuffer(star)
+	_fl := C.int32(fl)
+	_typ := C.int32(typ &^ swego.EclOneTry)
+	_backward := cbool(backward)
+
+	// The C library expects SE_ECL_ONE_TRY in the backward argument.
+	if typ&swego.EclOneTry != 0 {
+		_backward |= C.SE_ECL_ONE_TRY
+	}
+
+	var tret [10]float64
+	_tret := cDoubles(tret[:])
+
+	err = withError(func(err *C.char) bool {
+		rtyp = swego.EclType(C.swe_lun_occult_when_glob(_ut, _pl, &_star[0], _fl, _typ, _tret, _backward, err))
+		return rtyp == C.ERR
+	})
+
+	return tret[:], rtyp, err
+}
+
+func lunOccultWhenLoc(ut float64, pl swego.Planet, star string, fl int32, geo swego.GeoLoc, backward bool) (_, _ []float64, typ swego.EclType, err error) {
+	_ut := C.double(ut)
+	_pl := C.int32(pl)
+	_star := starBuffer(star)
+	_fl := C.int32(fl)
+	_geo := [3]C.double{C.double(geo.Long), C.double(geo.Lat), C.double(geo.Alt)}
+	_backward := cbool(backward)
+
+	var tret [10]float64
+	var attr [20]float64
+	_tret := cDoubles(tret[:])
+	_attr := cDoubles(attr[:])
+
+	err = withError(func(err *C.char) bool {
+		typ = swego.EclType(C.swe_lun_occult_when_loc(_ut, _pl, &_star[0], _fl, &_geo[0], _tret, _attr, _backward, err))
+		return typ == C.ERR
+	})
+
+	return tret[:], attr[:], typ, err
+}
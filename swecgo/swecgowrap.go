@@ -19,18 +19,102 @@ func (w *wrapper) SetPath(ephepath string) {
 	w.release()
 }
 
+func (w *wrapper) SetEphemeris(eph swego.Ephemeris, path, jplFile string) (*swego.CalcFlags, error) {
+	fl := swego.NewCalcFlags().Ephemeris(eph)
+	if eph == swego.JPL {
+		fl.JPLFile = jplFile
+	}
+
+	w.acquire()
+	setEphePath(path)
+	err := checkEphemeris(fl)
+	w.release()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fl, nil
+}
+
+func (w *wrapper) CheckEphemerisFiles(fromJD, toJD float64) ([]string, error) {
+	w.acquire()
+	missing, err := checkEphemerisFiles(fromJD, toJD)
+	w.release()
+	return missing, err
+}
+
 func (w *wrapper) Close() {
 	w.acquire()
 	closeEphemeris()
+	userDeltaT = resetDeltaT
 	w.release()
 }
 
-const resetDeltaT = -1e-10
+func (w *wrapper) SetTidAcc(tacc float64) {
+	w.acquire()
+	setTidAcc(tacc)
+	w.release()
+}
+
+func (w *wrapper) GetTidAcc() float64 {
+	w.acquire()
+	tacc := getTidAcc()
+	w.release()
+	return tacc
+}
+
+func (w *wrapper) SetAstroModels(samod string, eph swego.Ephemeris) {
+	w.acquire()
+	setAstroModels(samod, int32(eph))
+	w.release()
+}
+
+func (w *wrapper) GetAstroModels() string {
+	w.acquire()
+	samod := getAstroModels()
+	w.release()
+	return samod
+}
+
+func (w *wrapper) SidTimeModel(ut float64, model int, fl *swego.SidTimeFlags) float64 {
+	w.acquire()
+	setSidTimeDeltaT(fl)
+	f := sidTimeModel(ut, model)
+	w.release()
+	return f
+}
+
+func (w *wrapper) GetCurrentFileData(ifno int) (path string, tfstart, tfend float64, denum int, err error) {
+	w.acquire()
+	path, tfstart, tfend, denum, err = getCurrentFileData(ifno)
+	w.release()
+	return
+}
+
+func (w *wrapper) SetLapseRate(lrate float64) {
+	w.acquire()
+	setLapseRate(lrate)
+	w.release()
+}
+
+func (w *wrapper) SetDeltaTUserDef(dt float64) {
+	w.acquire()
+	userDeltaT = dt
+	setDeltaTUserDef(dt)
+	w.release()
+}
+
+const resetDeltaT = swego.DeltaTAutomatic
+
+// userDeltaT is the ΔT value used when no ΔT is set in a flags object. It is
+// protected by the wrapper lock.
+var userDeltaT float64 = resetDeltaT
 
 func setDeltaT(dt *float64) {
 	var f float64
 	if dt == nil {
-		f = resetDeltaT
+		f = userDeltaT
 	} else {
 		f = *dt
 	}
@@ -38,6 +122,16 @@ func setDeltaT(dt *float64) {
 	setDeltaTUserDef(f)
 }
 
+// checkTopoLoc validates the topocentric location in fl if FlagTopo is set.
+// It is called before the location is passed to swe_set_topo.
+func checkTopoLoc(fl *swego.CalcFlags) error {
+	if fl == nil || fl.TopoLoc == nil || (fl.Flags&flgTopo) != flgTopo {
+		return nil
+	}
+
+	return fl.TopoLoc.Validate()
+}
+
 func setCalcFlagsState(fl *swego.CalcFlags) int32 {
 	if fl == nil {
 		setDeltaT(nil)
@@ -69,11 +163,12 @@ func setCalcFlagsState(fl *swego.CalcFlags) int32 {
 		setSidMode(mode, t0, ayanT0)
 	}
 
-	if fl.JPLFile != "" {
-		fl.JPLFile = swego.FnameDft
+	jplFile := fl.JPLFile
+	if jplFile == "" {
+		jplFile = swego.FnameDft
 	}
 
-	setJPLFile(fl.JPLFile)
+	setJPLFile(jplFile)
 	setDeltaT(fl.DeltaT)
 	return fl.Flags
 }
@@ -85,7 +180,18 @@ func (w *wrapper) PlanetName(pl swego.Planet) (string, error) {
 	return name, nil
 }
 
+func (w *wrapper) PlanetNumber(name string) (swego.Planet, bool) {
+	w.acquire()
+	pl, ok := planetNumber(name)
+	w.release()
+	return pl, ok
+}
+
 func (w *wrapper) Calc(et float64, pl swego.Planet, fl *swego.CalcFlags) ([]float64, int, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, 0, err
+	}
+
 	w.acquire()
 	flags := setCalcFlagsState(fl)
 	xx, cfl, err := calc(et, pl, flags)
@@ -94,6 +200,10 @@ func (w *wrapper) Calc(et float64, pl swego.Planet, fl *swego.CalcFlags) ([]floa
 }
 
 func (w *wrapper) CalcUT(ut float64, pl swego.Planet, fl *swego.CalcFlags) ([]float64, int, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, 0, err
+	}
+
 	w.acquire()
 	flags := setCalcFlagsState(fl)
 	xx, cfl, err := calcUT(ut, pl, flags)
@@ -101,7 +211,74 @@ func (w *wrapper) CalcUT(ut float64, pl swego.Planet, fl *swego.CalcFlags) ([]fl
 	return xx, cfl, err
 }
 
+func (w *wrapper) CalcPctr(et float64, pl, plctr swego.Planet, fl *swego.CalcFlags) ([]float64, int, error) {
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	xx, cfl, err := calcPctr(et, pl, plctr, flags)
+	w.release()
+	return xx, cfl, err
+}
+
+func (w *wrapper) FixStar(star string, et float64, fl *swego.CalcFlags) (string, []float64, int, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return "", nil, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	name, xx, cfl, err := fixStar(star, et, flags)
+	w.release()
+	return name, xx, cfl, err
+}
+
+func (w *wrapper) FixStarUT(star string, ut float64, fl *swego.CalcFlags) (string, []float64, int, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return "", nil, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	name, xx, cfl, err := fixStarUT(star, ut, flags)
+	w.release()
+	return name, xx, cfl, err
+}
+
+func (w *wrapper) FixStar2(star string, et float64, fl *swego.CalcFlags) (string, []float64, int, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return "", nil, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	name, xx, cfl, err := fixStar2(star, et, flags)
+	w.release()
+	return name, xx, cfl, err
+}
+
+func (w *wrapper) FixStar2UT(star string, ut float64, fl *swego.CalcFlags) (string, []float64, int, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return "", nil, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	name, xx, cfl, err := fixStar2UT(star, ut, flags)
+	w.release()
+	return name, xx, cfl, err
+}
+
+func (w *wrapper) FixStarMag(star string) (string, float64, error) {
+	w.acquire()
+	name, mag, err := fixStarMag(star)
+	w.release()
+	return name, mag, err
+}
+
 func (w *wrapper) NodAps(et float64, pl swego.Planet, fl *swego.CalcFlags, m swego.NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
 	w.acquire()
 	flags := setCalcFlagsState(fl)
 	nasc, ndsc, peri, aphe, err = nodAps(et, pl, flags, m)
@@ -110,6 +287,10 @@ func (w *wrapper) NodAps(et float64, pl swego.Planet, fl *swego.CalcFlags, m swe
 }
 
 func (w *wrapper) NodApsUT(ut float64, pl swego.Planet, fl *swego.CalcFlags, m swego.NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
 	w.acquire()
 	flags := setCalcFlagsState(fl)
 	nasc, ndsc, peri, aphe, err = nodApsUT(ut, pl, flags, m)
@@ -117,6 +298,150 @@ func (w *wrapper) NodApsUT(ut float64, pl swego.Planet, fl *swego.CalcFlags, m s
 	return
 }
 
+func (w *wrapper) Pheno(et float64, pl swego.Planet, fl *swego.CalcFlags) ([]float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	attr, err := pheno(et, pl, flags)
+	w.release()
+	return attr, err
+}
+
+func (w *wrapper) PhenoUT(ut float64, pl swego.Planet, fl *swego.CalcFlags) ([]float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	attr, err := phenoUT(ut, pl, flags)
+	w.release()
+	return attr, err
+}
+
+func (w *wrapper) OrbitalElements(et float64, pl swego.Planet, fl *swego.CalcFlags) ([]float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	dret, err := orbitalElements(et, pl, flags)
+	w.release()
+	return dret, err
+}
+
+func (w *wrapper) OrbitMaxMinTrueDistance(et float64, pl swego.Planet, fl *swego.CalcFlags) (dmax, dmin, dtrue float64, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, 0, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	dmax, dmin, dtrue, err = orbitMaxMinTrueDistance(et, pl, flags)
+	w.release()
+	return
+}
+
+func (w *wrapper) SolCross(x2cross, et float64, fl *swego.CalcFlags, backward bool) (float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	jd, err := solCross(x2cross, et, flags, backward)
+	w.release()
+	return jd, err
+}
+
+func (w *wrapper) SolCrossUT(x2cross, ut float64, fl *swego.CalcFlags, backward bool) (float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	jd, err := solCrossUT(x2cross, ut, flags, backward)
+	w.release()
+	return jd, err
+}
+
+func (w *wrapper) MoonCross(x2cross, et float64, fl *swego.CalcFlags) (float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	jd, err := moonCross(x2cross, et, flags)
+	w.release()
+	return jd, err
+}
+
+func (w *wrapper) MoonCrossUT(x2cross, ut float64, fl *swego.CalcFlags) (float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	jd, err := moonCrossUT(x2cross, ut, flags)
+	w.release()
+	return jd, err
+}
+
+func (w *wrapper) MoonCrossNode(et float64, fl *swego.CalcFlags) (jd, xlon, xlat float64, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, 0, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	jd, xlon, xlat, err = moonCrossNode(et, flags)
+	w.release()
+	return
+}
+
+func (w *wrapper) MoonCrossNodeUT(ut float64, fl *swego.CalcFlags) (jd, xlon, xlat float64, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, 0, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	jd, xlon, xlat, err = moonCrossNodeUT(ut, flags)
+	w.release()
+	return
+}
+
+func (w *wrapper) HelioCross(pl swego.Planet, x2cross, et float64, fl *swego.CalcFlags, backward bool) (float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	jd, err := helioCross(pl, x2cross, et, flags, backward)
+	w.release()
+	return jd, err
+}
+
+func (w *wrapper) HelioCrossUT(pl swego.Planet, x2cross, ut float64, fl *swego.CalcFlags, backward bool) (float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	jd, err := helioCrossUT(pl, x2cross, ut, flags, backward)
+	w.release()
+	return jd, err
+}
+
 func (w *wrapper) GetAyanamsaEx(et float64, fl *swego.AyanamsaExFlags) (float64, error) {
 	w.acquire()
 	setSidMode(fl.SidMode.Mode, fl.SidMode.T0, fl.SidMode.AyanT0)
@@ -145,6 +470,10 @@ func (w *wrapper) JulDay(y, m, d int, h float64, ct swego.CalType) (float64, err
 	return jd, nil
 }
 
+func (w *wrapper) DateConversion(y, m, d int, h float64, ct swego.CalType) (float64, error) {
+	return dateConversion(y, m, d, h, ct)
+}
+
 func (w *wrapper) RevJul(jd float64, ct swego.CalType) (y, m, d int, h float64, err error) {
 	y, m, d, h = revJul(jd, int(ct))
 	return y, m, d, h, nil
@@ -174,7 +503,16 @@ func (w *wrapper) JdUT1ToUTC(ut1 float64, fl *swego.DateConvertFlags) (y, m, d,
 	return y, m, d, h, i, s, nil
 }
 
-func (w *wrapper) HousesEx(ut float64, fl *swego.HousesExFlags, geolat, geolon float64, hsys swego.HSys) ([]float64, []float64, error) {
+func (w *wrapper) DayOfWeek(jd float64) (int, error) {
+	return dayOfWeek(jd), nil
+}
+
+func (w *wrapper) HousesEx(ut float64, fl *swego.HousesExFlags, geo *swego.GeoLoc, hsys swego.HSys) ([]float64, []float64, error) {
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
 	w.acquire()
 	var flags int32
 	if fl != nil {
@@ -188,7 +526,7 @@ func (w *wrapper) HousesEx(ut float64, fl *swego.HousesExFlags, geolat, geolon f
 		setDeltaT(nil)
 	}
 
-	cusps, ascmc, err := housesEx(ut, flags, geolat, geolon, hsys)
+	cusps, ascmc, err := housesEx(ut, flags, loc.Lat, loc.Long, hsys)
 	w.release()
 	return cusps, ascmc, err
 }
@@ -214,8 +552,26 @@ func (w *wrapper) HouseName(hsys swego.HSys) (string, error) {
 	return name, nil
 }
 
+func (w *wrapper) GauquelinSector(ut float64, pl swego.Planet, star string, fl *swego.CalcFlags, m swego.GauquelinMethod, geo *swego.GeoLoc, press, temp float64) (float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, err
+	}
+
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	pos, err := gauquelinSector(ut, pl, star, flags, m, loc, press, temp)
+	w.release()
+	return pos, err
+}
+
 func (w *wrapper) DeltaTEx(jd float64, eph swego.Ephemeris) (float64, error) {
 	w.acquire()
+	setDeltaT(nil) // drop the ΔT of the flags of a previous call
 	dt, err := deltaTEx(jd, int32(eph))
 	w.release()
 	return dt, err
@@ -276,3 +632,316 @@ func (w *wrapper) SidTime(ut float64, fl *swego.SidTimeFlags) (float64, error) {
 	w.release()
 	return f, nil
 }
+
+func setAzaltDeltaT(fl *swego.AzaltFlags) {
+	if fl == nil {
+		setDeltaT(nil)
+	} else {
+		setDeltaT(fl.DeltaT)
+	}
+}
+
+func (w *wrapper) Azalt(ut float64, m swego.AzaltMode, geo *swego.GeoLoc, press, temp float64, in [3]float64, fl *swego.AzaltFlags) ([]float64, error) {
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
+	w.acquire()
+	setAzaltDeltaT(fl)
+	out := azalt(ut, m, loc, press, temp, in)
+	w.release()
+	return out, nil
+}
+
+func (w *wrapper) AzaltRev(ut float64, m swego.AzaltRevMode, geo *swego.GeoLoc, in [2]float64, fl *swego.AzaltFlags) ([]float64, error) {
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
+	w.acquire()
+	setAzaltDeltaT(fl)
+	out := azaltRev(ut, m, loc, in)
+	w.release()
+	return out, nil
+}
+
+func (w *wrapper) Refrac(alt, press, temp float64, m swego.RefracMode) (float64, error) {
+	return refrac(alt, press, temp, m), nil
+}
+
+func (w *wrapper) RefracExtended(alt, geoalt, press, temp, lapseRate float64, m swego.RefracMode) (float64, []float64, error) {
+	f, dret := refracExtended(alt, geoalt, press, temp, lapseRate, m)
+	return f, dret, nil
+}
+
+func (w *wrapper) RiseTrans(ut float64, pl swego.Planet, star string, fl *swego.CalcFlags, m swego.RiseTransMode, geo *swego.GeoLoc, press, temp float64) (float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, err
+	}
+
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	tret, err := riseTrans(ut, pl, star, flags, m, loc, press, temp)
+	w.release()
+	return tret, err
+}
+
+func (w *wrapper) RiseTransTrueHor(ut float64, pl swego.Planet, star string, fl *swego.CalcFlags, m swego.RiseTransMode, geo *swego.GeoLoc, press, temp, horhgt float64) (float64, error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return 0, err
+	}
+
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	tret, err := riseTransTrueHor(ut, pl, star, flags, m, loc, press, temp, horhgt)
+	w.release()
+	return tret, err
+}
+
+func (w *wrapper) Cotrans(in [3]float64, eps float64) ([]float64, error) {
+	return cotrans(in, eps), nil
+}
+
+func (w *wrapper) CotransSp(in [6]float64, eps float64) ([]float64, error) {
+	return cotransSp(in, eps), nil
+}
+
+func (w *wrapper) DegNorm(x float64) (float64, error) {
+	return degNorm(x), nil
+}
+
+func (w *wrapper) RadNorm(x float64) (float64, error) {
+	return radNorm(x), nil
+}
+
+func (w *wrapper) DegMidp(x1, x0 float64) (float64, error) {
+	return degMidp(x1, x0), nil
+}
+
+func (w *wrapper) RadMidp(x1, x0 float64) (float64, error) {
+	return radMidp(x1, x0), nil
+}
+
+func (w *wrapper) SplitDeg(x float64, fl swego.SplitDegFlags) (deg, min, sec int, secfr float64, sign int, err error) {
+	deg, min, sec, secfr, sign = splitDeg(x, fl)
+	return deg, min, sec, secfr, sign, nil
+}
+
+type heliacalArgs struct {
+	geo swego.GeoLoc
+	atm swego.Atmosphere
+	obs swego.Observer
+}
+
+func newHeliacalArgs(geo *swego.GeoLoc, atm *swego.Atmosphere, obs *swego.Observer) (args heliacalArgs) {
+	if geo != nil {
+		args.geo = *geo
+	}
+
+	if atm != nil {
+		args.atm = *atm
+	}
+
+	if obs != nil {
+		args.obs = *obs
+	}
+
+	return
+}
+
+func setHeliacalFlagsState(fl *swego.HeliacalFlags) int32 {
+	if fl == nil {
+		setDeltaT(nil)
+		return 0
+	}
+
+	setDeltaT(fl.DeltaT)
+	return fl.Flags
+}
+
+func (w *wrapper) HeliacalUT(ut float64, geo *swego.GeoLoc, atm *swego.Atmosphere, obs *swego.Observer, obj string, ev swego.HeliacalEvent, fl *swego.HeliacalFlags) ([]float64, error) {
+	args := newHeliacalArgs(geo, atm, obs)
+
+	w.acquire()
+	flags := setHeliacalFlagsState(fl)
+	dret, err := heliacalUT(ut, args.geo, args.atm, args.obs, obj, ev, flags)
+	w.release()
+	return dret, err
+}
+
+func (w *wrapper) HeliacalPhenoUT(ut float64, geo *swego.GeoLoc, atm *swego.Atmosphere, obs *swego.Observer, obj string, ev swego.HeliacalEvent, fl *swego.HeliacalFlags) ([]float64, error) {
+	args := newHeliacalArgs(geo, atm, obs)
+
+	w.acquire()
+	flags := setHeliacalFlagsState(fl)
+	darr, err := heliacalPhenoUT(ut, args.geo, args.atm, args.obs, obj, ev, flags)
+	w.release()
+	return darr, err
+}
+
+func (w *wrapper) VisLimitMag(ut float64, geo *swego.GeoLoc, atm *swego.Atmosphere, obs *swego.Observer, obj string, fl *swego.HeliacalFlags) ([]float64, bool, error) {
+	args := newHeliacalArgs(geo, atm, obs)
+
+	w.acquire()
+	flags := setHeliacalFlagsState(fl)
+	dret, visible, err := visLimitMag(ut, args.geo, args.atm, args.obs, obj, flags)
+	w.release()
+	return dret, visible, err
+}
+
+func (w *wrapper) SolEclipseWhere(ut float64, fl *swego.CalcFlags) (geo, attr []float64, typ swego.EclType, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, nil, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	geo, attr, typ, err = solEclipseWhere(ut, flags)
+	w.release()
+	return
+}
+
+func (w *wrapper) SolEclipseHow(ut float64, fl *swego.CalcFlags, geo *swego.GeoLoc) (attr []float64, typ swego.EclType, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, 0, err
+	}
+
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	attr, typ, err = solEclipseHow(ut, flags, loc)
+	w.release()
+	return
+}
+
+func (w *wrapper) SolEclipseWhenGlob(ut float64, fl *swego.CalcFlags, typ swego.EclType, backward bool) (tret []float64, rtyp swego.EclType, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	tret, rtyp, err = solEclipseWhenGlob(ut, flags, typ, backward)
+	w.release()
+	return
+}
+
+func (w *wrapper) SolEclipseWhenLoc(ut float64, fl *swego.CalcFlags, geo *swego.GeoLoc, backward bool) (tret, attr []float64, typ swego.EclType, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, nil, 0, err
+	}
+
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	tret, attr, typ, err = solEclipseWhenLoc(ut, flags, loc, backward)
+	w.release()
+	return
+}
+
+func (w *wrapper) LunEclipseHow(ut float64, fl *swego.CalcFlags, geo *swego.GeoLoc) (attr []float64, typ swego.EclType, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, 0, err
+	}
+
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	attr, typ, err = lunEclipseHow(ut, flags, loc)
+	w.release()
+	return
+}
+
+func (w *wrapper) LunEclipseWhen(ut float64, fl *swego.CalcFlags, typ swego.EclType, backward bool) (tret []float64, rtyp swego.EclType, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	tret, rtyp, err = lunEclipseWhen(ut, flags, typ, backward)
+	w.release()
+	return
+}
+
+func (w *wrapper) LunEclipseWhenLoc(ut float64, fl *swego.CalcFlags, geo *swego.GeoLoc, backward bool) (tret, attr []float64, typ swego.EclType, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, nil, 0, err
+	}
+
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	tret, attr, typ, err = lunEclipseWhenLoc(ut, flags, loc, backward)
+	w.release()
+	return
+}
+
+func (w *wrapper) LunOccultWhere(ut float64, pl swego.Planet, star string, fl *swego.CalcFlags) (geo, attr []float64, typ swego.EclType, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, nil, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	geo, attr, typ, err = lunOccultWhere(ut, pl, star, flags)
+	w.release()
+	return
+}
+
+func (w *wrapper) LunOccultWhenGlob(ut float64, pl swego.Planet, star string, fl *swego.CalcFlags, typ swego.EclType, backward bool) (tret []float64, rtyp swego.EclType, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, 0, err
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	tret, rtyp, err = lunOccultWhenGlob(ut, pl, star, flags, typ, backward)
+	w.release()
+	return
+}
+
+func (w *wrapper) LunOccultWhenLoc(ut float64, pl swego.Planet, star string, fl *swego.CalcFlags, geo *swego.GeoLoc, backward bool) (tret, attr []float64, typ swego.EclType, err error) {
+	if err := checkTopoLoc(fl); err != nil {
+		return nil, nil, 0, err
+	}
+
+	var loc swego.GeoLoc
+	if geo != nil {
+		loc = *geo
+	}
+
+	w.acquire()
+	flags := setCalcFlagsState(fl)
+	tret, attr, typ, err = lunOccultWhenLoc(ut, pl, star, flags, loc, backward)
+	w.release()
+	return
+}
@@ -1,40 +1,231 @@
 // Package swego defines an interface for interfacing with the Swiss Ephemeris.
 package swego
 
-// Error represents an error reported by the Swiss Ephemeris library.
-type Error string
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Error represents an error reported by the Swiss Ephemeris library. Use
+// errors.As to inspect the return code and message of the failed call.
+type Error struct {
+	Code    int    // return code of the C function, negative for errors
+	Message string // error message reported by the C function
+	Warning bool   // the results of the call are valid, see IsWarning
+}
 
 func (e Error) Error() string {
-	return "swisseph: " + string(e)
+	return "swisseph: " + e.Message
+}
+
+// IsWarning reports whether err is a warning reported by the Swiss Ephemeris
+// library. A warning is an Error with field Warning set. The results of the
+// call are valid if err is a warning. Only Calc, CalcUT, FixStar,
+// FixStarUT, HousesEx, HousesARMC and DeltaTEx report warnings.
+func IsWarning(err error) bool {
+	var e Error
+	return errors.As(err, &e) && e.Warning
+}
+
+// ErrNoRiseSet is returned by RiseTrans and RiseTransTrueHor if the body does
+// not rise or set within the searched period, for example for circumpolar
+// bodies.
+var ErrNoRiseSet = Error{Code: -2, Message: "body does not rise or set"}
+
+// ErrInvalidDate is returned by DateConversion if the given date does not
+// exist in the calendar, for example 30 February.
+var ErrInvalidDate = Error{Code: -1, Message: "invalid date"}
+
+// ErrDateOutOfRange is matched by errors.Is for a *DateRangeError.
+var ErrDateOutOfRange = Error{Code: -1, Message: "date outside ephemeris range"}
+
+// DateRangeError is returned by the functions that compute the positions of
+// the bodies, like Calc, FixStar, NodAps, Pheno, OrbitalElements and the
+// crossing functions, if the date is outside the range of the ephemeris, like
+// the range of the JPL ephemeris file or of the Moshier ephemeris. All dates
+// are Julian Dates in Terrestrial Time, also for the UT functions. Use RevJul
+// to present the range as calendar dates.
+type DateRangeError struct {
+	JD        float64 // requested date
+	Start     float64 // first date of the ephemeris
+	End       float64 // last date of the ephemeris
+	Ephemeris string  // ephemeris as named by the library, e.g. "JPL eph."
+	Message   string  // error message of the library
+}
+
+func (e *DateRangeError) Error() string {
+	return "swisseph: " + e.Message
 }
 
+// Is reports whether target is ErrDateOutOfRange.
+func (e *DateRangeError) Is(target error) bool {
+	return target == ErrDateOutOfRange
+}
+
+// ErrShortResult is returned by helpers such as NextStation, NextAspect and
+// ComputeChart if the library handle returns fewer values than the helper
+// needs, like Null, which returns nil slices.
+var ErrShortResult = Error{Code: -1, Message: "result has too few values"}
+
+// ErrHouseFallback is a warning returned by HousesEx and HousesARMC if the
+// house system is not defined at the latitude, such as Placidus, Koch and the
+// Gauquelin sectors within the polar circles. The returned cusps are those of
+// the Porphyrius house system, the Ascendant, MC and other positions are valid.
+var ErrHouseFallback = Error{Code: 0, Message: "within polar circle, switched to Porphyry", Warning: true}
+
 // Planet is the type of planet constants.
 type Planet int
 
 // Ayanamsa is the type of sidereal mode constants.
 type Ayanamsa int32
 
-// SidMode represents library state changed by swe_set_sid_mode.
+// AyanamsaName returns the name of sidereal mode mode as returned by
+// GetAyanamsaName without calling into the library. Option bits such as
+// SidbitEclT0 are ignored. It returns an empty string for SidmUser and
+// unknown modes.
+func AyanamsaName(mode Ayanamsa) string {
+	mode %= SidbitEclT0
+	if mode < 0 || int(mode) >= len(ayanamsaNames) {
+		return ""
+	}
+
+	return ayanamsaNames[mode]
+}
+
+// AyanamsaMode is a sidereal mode and its name, see AyanamsaModes.
+type AyanamsaMode struct {
+	Mode Ayanamsa
+	Name string
+}
+
+// AyanamsaModes returns the predefined sidereal modes supported by the C
+// library behind swe, in order of their mode number. The modes below SidmUser
+// are probed with GetAyanamsaName and modes without a name are skipped, so the
+// list follows the version of the library instead of the Sidm constants.
+func AyanamsaModes(swe Interface) ([]AyanamsaMode, error) {
+	var modes []AyanamsaMode
+	for m := SidmFaganBradley; m < SidmUser; m++ {
+		name, err := swe.GetAyanamsaName(m)
+		if err != nil {
+			return nil, err
+		}
+
+		if name != "" {
+			modes = append(modes, AyanamsaMode{Mode: m, Name: name})
+		}
+	}
+
+	return modes, nil
+}
+
+// ayanamsaNames contains the names of the predefined sidereal modes in
+// sweph.c.
+var ayanamsaNames = [...]string{
+	"Fagan/Bradley",
+	"Lahiri",
+	"De Luce",
+	"Raman",
+	"Usha/Shashi",
+	"Krishnamurti",
+	"Djwhal Khul",
+	"Yukteshwar",
+	"J.N. Bhasin",
+	"Babylonian/Kugler 1",
+	"Babylonian/Kugler 2",
+	"Babylonian/Kugler 3",
+	"Babylonian/Huber",
+	"Babylonian/Eta Piscium",
+	"Babylonian/Aldebaran = 15 Tau",
+	"Hipparchos",
+	"Sassanian",
+	"Galact. Center = 0 Sag",
+	"J2000",
+	"J1900",
+	"B1950",
+	"Suryasiddhanta",
+	"Suryasiddhanta, mean Sun",
+	"Aryabhata",
+	"Aryabhata, mean Sun",
+	"SS Revati",
+	"SS Citra",
+	"True Citra",
+	"True Revati",
+	"True Pushya (PVRN Rao)",
+	"Galactic Center (Gil Brand)",
+	"Galactic Equator (IAU1958)",
+	"Galactic Equator",
+	"Galactic Equator mid-Mula",
+	"Skydram (Mardyks)",
+	"True Mula (Chandra Hari)",
+	"Dhruva/Gal.Center/Mula (Wilhelm)",
+	"Aryabhata 522",
+	"Babylonian/Britton",
+}
+
+// SidMode represents library state changed by swe_set_sid_mode. T0 and AyanT0
+// are only used by SidmUser, the predefined sidereal modes ignore them.
 type SidMode struct {
 	Mode   Ayanamsa
-	T0     float64
-	AyanT0 float64
+	T0     float64 // reference date of a user-defined ayanamsa, Julian Date in TT or UT with SidbitUserUT
+	AyanT0 float64 // ayanamsa in degrees at T0
 }
 
-// GeoLoc represents a geographic location.
+// GeoLoc represents a geographic location. Longitude Long and latitude Lat
+// are in degrees, altitude Alt is in meters above sea level.
 type GeoLoc struct {
 	Long float64
 	Lat  float64
 	Alt  float64
 }
 
-// CalcFlags represents the library state of swe_calc and swe_calc_ut.
+// Limits of the altitude of a geographic location in meters, see
+// GeoLoc.Validate.
+const (
+	MinAltitude = -12000
+	MaxAltitude = 50000
+)
+
+// Validate returns an error if the latitude is not within [-90, 90], the
+// longitude not within [-180, 180] or the altitude not within [MinAltitude,
+// MaxAltitude] meters. The topocentric calculations validate CalcFlags.TopoLoc
+// before setting it in the C library.
+func (g GeoLoc) Validate() error {
+	var field string
+	var value float64
+
+	switch {
+	case !(g.Lat >= -90 && g.Lat <= 90):
+		field, value = "latitude", g.Lat
+	case !(g.Long >= -180 && g.Long <= 180):
+		field, value = "longitude", g.Long
+	case !(g.Alt >= MinAltitude && g.Alt <= MaxAltitude):
+		field, value = "altitude", g.Alt
+	default:
+		return nil
+	}
+
+	return Error{Code: -1, Message: "invalid geographic location: " + field + " " + formatFloats(value)}
+}
+
+// Array returns the location in the order of the geopos arrays of the C
+// library: longitude, latitude and altitude.
+func (g GeoLoc) Array() [3]float64 { return [3]float64{g.Long, g.Lat, g.Alt} }
+
+// CalcFlags represents the library state of swe_calc and swe_calc_ut. By
+// default positions are geocentric and ecliptic, relative to the true equinox
+// of date. Set FlagJ2000 for the equinox of J2000, see also CalcJ2000.
+//
+// With FlagTopo set, TopoLoc is the observer location; its altitude is in
+// meters, not kilometers or feet. Calculations return an error if the location
+// is out of range, see GeoLoc.Validate.
 type CalcFlags struct {
 	Flags   int32
-	TopoLoc *GeoLoc  // Arguments to swe_set_topo
+	TopoLoc *GeoLoc  // Arguments to swe_set_topo, altitude in meters
 	SidMode *SidMode // Arguments to swe_set_sid_mode
 	JPLFile string   // Argument to swe_set_jpl_file
-	DeltaT  *float64 // Argument to swe_set_delta_t_userdef, nil resets it.
+	DeltaT  *float64 // Argument to swe_set_delta_t_userdef, see SetDeltaT.
 }
 
 // Copy returns a copy of the calculation flags fl.
@@ -51,22 +242,327 @@ type Ephemeris int32
 func (fl *CalcFlags) SetEphemeris(eph Ephemeris) { fl.Flags |= int32(eph) }
 
 // SetDeltaT sets f as delta T in flags object fl.
-// Set fl.DeltaT to nil to reset the value within the Swiss Ephemeris.
+// Set fl.DeltaT to nil to use the ΔT set by SetDeltaTUserDef of the library,
+// by default the internal ΔT model.
 func (fl *CalcFlags) SetDeltaT(f float64) { fl.DeltaT = &f }
 
+// NewCalcFlags returns a new calculation flags object. The methods Speed,
+// Topocentric, Sidereal and Ephemeris return the flags object, so the calls
+// can be chained:
+//
+//	fl := NewCalcFlags().Speed().Topocentric(lat, long, alt).Ephemeris(Swiss)
+func NewCalcFlags() *CalcFlags { return new(CalcFlags) }
+
+// Speed sets FlagSpeed in fl and returns fl.
+func (fl *CalcFlags) Speed() *CalcFlags {
+	fl.Flags |= FlagSpeed
+	return fl
+}
+
+// Topocentric sets FlagTopo and the topocentric location in fl and returns
+// fl. Latitude lat and longitude long are in degrees, altitude alt is in
+// meters above sea level.
+func (fl *CalcFlags) Topocentric(lat, long, alt float64) *CalcFlags {
+	fl.Flags |= FlagTopo
+	fl.TopoLoc = &GeoLoc{Long: long, Lat: lat, Alt: alt}
+	return fl
+}
+
+// Sidereal sets FlagSidereal and sidereal mode mode in fl and returns fl.
+func (fl *CalcFlags) Sidereal(mode Ayanamsa) *CalcFlags {
+	fl.Flags |= FlagSidereal
+	fl.SidMode = &SidMode{Mode: mode}
+	return fl
+}
+
+// Ephemeris replaces the ephemeris flag in fl with eph and returns fl.
+func (fl *CalcFlags) Ephemeris(eph Ephemeris) *CalcFlags {
+	fl.Flags &^= FlagEphJPL | FlagEphSwiss | FlagEphMoshier
+	fl.SetEphemeris(eph)
+	return fl
+}
+
+// calcFlagNames contains the names of the calculation flags in swephexp.h.
+var calcFlagNames = []struct {
+	flag int32
+	name string
+}{
+	{FlagEphJPL, "SEFLG_JPLEPH"},
+	{FlagEphSwiss, "SEFLG_SWIEPH"},
+	{FlagEphMoshier, "SEFLG_MOSEPH"},
+	{FlagHelio, "SEFLG_HELCTR"},
+	{FlagTruePos, "SEFLG_TRUEPOS"},
+	{FlagJ2000, "SEFLG_J2000"},
+	{FlagNoNut, "SEFLG_NONUT"},
+	{FlagSpeed, "SEFLG_SPEED"},
+	{FlagNoGDefl, "SEFLG_NOGDEFL"},
+	{FlagNoAbber, "SEFLG_NOABERR"},
+	{FlagEquatorial, "SEFLG_EQUATORIAL"},
+	{FlagXYZ, "SEFLG_XYZ"},
+	{FlagRadians, "SEFLG_RADIANS"},
+	{FlagBary, "SEFLG_BARYCTR"},
+	{FlagTopo, "SEFLG_TOPOCTR"},
+	{FlagSidereal, "SEFLG_SIDEREAL"},
+	{FlagICRS, "SEFLG_ICRS"},
+	{FlagJPLHor, "SEFLG_JPLHOR"},
+	{FlagJPLHorApprox, "SEFLG_JPLHOR_APPROX"},
+}
+
+// String returns the names of the flags set in fl.Flags separated by "|",
+// followed by the topocentric location, the sidereal mode, the JPL file and
+// ΔT if set, for example:
+//
+//	SEFLG_SWIEPH|SEFLG_SPEED|TOPO(52.5,13.4,34)|SID(1,0,0)
+//
+// Unknown flags are printed as hexadecimal number. It is intended for
+// debugging and logging.
+func (fl *CalcFlags) String() string {
+	if fl == nil {
+		return "<nil>"
+	}
+
+	var parts []string
+	rest := fl.Flags
+	for _, f := range calcFlagNames {
+		if rest&f.flag != 0 {
+			parts = append(parts, f.name)
+			rest &^= f.flag
+		}
+	}
+
+	if rest != 0 {
+		parts = append(parts, "0x"+strconv.FormatInt(int64(rest), 16))
+	}
+
+	if len(parts) == 0 {
+		parts = append(parts, "0")
+	}
+
+	if l := fl.TopoLoc; l != nil {
+		parts = append(parts, "TOPO("+formatFloats(l.Lat, l.Long, l.Alt)+")")
+	}
+
+	if m := fl.SidMode; m != nil {
+		parts = append(parts, "SID("+strconv.Itoa(int(m.Mode))+","+formatFloats(m.T0, m.AyanT0)+")")
+	}
+
+	if fl.JPLFile != "" {
+		parts = append(parts, "JPL("+fl.JPLFile+")")
+	}
+
+	if fl.DeltaT != nil {
+		parts = append(parts, "DELTAT("+formatFloats(*fl.DeltaT)+")")
+	}
+
+	return strings.Join(parts, "|")
+}
+
+func formatFloats(fs ...float64) string {
+	s := make([]string, len(fs))
+	for i, f := range fs {
+		s[i] = strconv.FormatFloat(f, 'g', -1, 64)
+	}
+
+	return strings.Join(s, ",")
+}
+
 // NodApsMethod is the type of Nodbit constants.
 type NodApsMethod int32
 
+// PhenoData represents the planetary phenomena computed by swe_pheno and
+// swe_pheno_ut.
+type PhenoData struct {
+	PhaseAngle        float64 `json:"phaseAngle"`       // angle between sun, planet and earth in degrees
+	Phase             float64 `json:"phase"`            // illuminated fraction of the disc
+	Elongation        float64 `json:"elongation"`       // elongation of the planet in degrees
+	ApparentDiameter  float64 `json:"apparentDiameter"` // apparent diameter of the disc in degrees
+	ApparentMagnitude float64 `json:"apparentMagnitude"`
+}
+
+// NewPhenoData returns the planetary phenomena stored in attr as returned by
+// Pheno and PhenoUT. It panics if attr contains less than 5 elements.
+func NewPhenoData(attr []float64) PhenoData {
+	return PhenoData{
+		PhaseAngle:        attr[0],
+		Phase:             attr[1],
+		Elongation:        attr[2],
+		ApparentDiameter:  attr[3],
+		ApparentMagnitude: attr[4],
+	}
+}
+
+// OrbitElements represents the osculating orbital elements computed by
+// swe_get_orbital_elements. Angles are in degrees.
+type OrbitElements struct {
+	SemiMajorAxis  float64 `json:"semiMajorAxis"` // in AU
+	Eccentricity   float64 `json:"eccentricity"`
+	Inclination    float64 `json:"inclination"`
+	AscNode        float64 `json:"ascNode"`        // longitude of ascending node
+	ArgPeri        float64 `json:"argPeri"`        // argument of periapsis
+	LongPeri       float64 `json:"longPeri"`       // longitude of periapsis
+	MeanAnomaly    float64 `json:"meanAnomaly"`    // at epoch
+	TrueAnomaly    float64 `json:"trueAnomaly"`    // at epoch
+	EccAnomaly     float64 `json:"eccAnomaly"`     // at epoch
+	MeanLongitude  float64 `json:"meanLongitude"`  // at epoch
+	SiderealPeriod float64 `json:"siderealPeriod"` // in tropical years
+	DailyMotion    float64 `json:"dailyMotion"`    // mean daily motion
+	TropicalPeriod float64 `json:"tropicalPeriod"` // in years
+	SynodicPeriod  float64 `json:"synodicPeriod"`  // in days, negative for inner planets and the moon
+	PeriPassage    float64 `json:"periPassage"`    // Julian Date of perihelion passage
+	PeriDistance   float64 `json:"periDistance"`   // in AU
+	ApheDistance   float64 `json:"apheDistance"`   // in AU
+}
+
+// NewOrbitElements returns the orbital elements stored in dret as returned by
+// OrbitalElements. It panics if dret contains less than 17 elements.
+func NewOrbitElements(dret []float64) OrbitElements {
+	return OrbitElements{
+		SemiMajorAxis:  dret[0],
+		Eccentricity:   dret[1],
+		Inclination:    dret[2],
+		AscNode:        dret[3],
+		ArgPeri:        dret[4],
+		LongPeri:       dret[5],
+		MeanAnomaly:    dret[6],
+		TrueAnomaly:    dret[7],
+		EccAnomaly:     dret[8],
+		MeanLongitude:  dret[9],
+		SiderealPeriod: dret[10],
+		DailyMotion:    dret[11],
+		TropicalPeriod: dret[12],
+		SynodicPeriod:  dret[13],
+		PeriPassage:    dret[14],
+		PeriDistance:   dret[15],
+		ApheDistance:   dret[16],
+	}
+}
+
+// HousesResult represents the house cusps and related positions computed by
+// swe_houses_ex and swe_houses_armc. All values are in degrees. In JSON Cusps
+// is encoded as an array including the unused element at index 0.
+type HousesResult struct {
+	// Cusps contains the house cusps. Index 0 is unused, so house n starts at
+	// Cusps[n]. It has 13 entries for 12 cusps, or 37 entries for the 36
+	// sectors of the Gauquelin house system. The length is taken from the
+	// cusps passed to NewHousesResult, so use len(Cusps)-1 as number of houses.
+	Cusps []float64 `json:"cusps"`
+
+	Ascendant           float64 `json:"ascendant"`
+	MC                  float64 `json:"mc"`   // medium coeli
+	ARMC                float64 `json:"armc"` // right ascension of the MC, also known as RAMC
+	Vertex              float64 `json:"vertex"`
+	EquatorialAscendant float64 `json:"equatorialAscendant"`
+	CoAscKoch           float64 `json:"coAscKoch"`      // co-ascendant as defined by W. Koch
+	CoAscMunkasey       float64 `json:"coAscMunkasey"`  // co-ascendant as defined by M. Munkasey
+	PolarAscendant      float64 `json:"polarAscendant"` // polar ascendant as defined by M. Munkasey
+}
+
+// NewHousesResult returns the house cusps and related positions stored in
+// cusps and ascmc as returned by HousesEx and HousesARMC. It panics if ascmc
+// contains less than 8 elements.
+func NewHousesResult(cusps, ascmc []float64) HousesResult {
+	return HousesResult{
+		Cusps:               cusps,
+		Ascendant:           ascmc[0],
+		MC:                  ascmc[1],
+		ARMC:                ascmc[2],
+		Vertex:              ascmc[3],
+		EquatorialAscendant: ascmc[4],
+		CoAscKoch:           ascmc[5],
+		CoAscMunkasey:       ascmc[6],
+		PolarAscendant:      ascmc[7],
+	}
+}
+
+// HousePosEcl returns the house position of ecliptic longitude xlon (in
+// degrees) within cusps as returned by HousesEx and HousesARMC. The position is
+// interpolated linearly along the ecliptic, so it equals the result of
+// HousePos only for planets on the ecliptic and for house systems with cusps
+// dividing the ecliptic, such as the equal and whole sign house systems. It
+// is computed without calling into the library. The position is in the range
+// 1 <= pos < len(cusps). It panics if cusps contains less than 2 elements.
+// It can not be used for the Gauquelin sectors, which are counted against the
+// zodiac.
+func HousePosEcl(cusps []float64, xlon float64) float64 {
+	n := len(cusps) - 1
+	if n < 1 {
+		panic("no house cusps")
+	}
+
+	for i := 1; i <= n; i++ {
+		start, end := cusps[i], cusps[i%n+1]
+		width := degNorm(end - start)
+		if n == 1 {
+			width = 360
+		}
+
+		if off := degNorm(xlon - start); off < width {
+			return float64(i) + off/width
+		}
+	}
+
+	return 1 // only reached due to rounding at the first cusp
+}
+
+// LongitudeFromHousePos returns the ecliptic longitude (in degrees) of house
+// position pos within cusps as returned by HousesEx and HousesARMC for house
+// system hsys, e.g. the middle of house 5 for pos 5.5. It is the inverse of
+// HousePosEcl and is computed without calling into the library. The inverse
+// of HousePos is only well defined for house systems dividing the ecliptic in
+// equal arcs per house or quadrant: the equal house systems, whole sign and
+// Porphyrius. An error is returned for other house systems and if pos is
+// outside the range 1 <= pos < len(cusps). It panics if cusps contains less
+// than 2 elements.
+func LongitudeFromHousePos(cusps []float64, hsys HSys, pos float64) (float64, error) {
+	n := len(cusps) - 1
+	if n < 1 {
+		panic("no house cusps")
+	}
+
+	switch hsys {
+	case Equal, 'A', EqualMC, EqualAsc, VehlowEqual, WholeSign, Porphyrius:
+	default:
+		return 0, Error{Code: -1, Message: "no inverse house position for house system: " + hsys.String()}
+	}
+
+	if !(pos >= 1 && pos < float64(n+1)) {
+		return 0, Error{Code: -1, Message: "house position out of range"}
+	}
+
+	i := int(pos)
+	start, end := cusps[i], cusps[i%n+1]
+	width := degNorm(end - start)
+	if n == 1 {
+		width = 360
+	}
+
+	return degNorm(start + (pos-float64(i))*width), nil
+}
+
+func degNorm(x float64) float64 {
+	x = math.Mod(x, 360)
+	if x < 0 {
+		x += 360
+	}
+
+	if x >= 360 {
+		x = 0
+	}
+
+	return x
+}
+
 // AyanamsaExFlags represents the library state of swe_get_ayanamsa_ex and
 // swe_get_ayanamsa_ex_ut.
 type AyanamsaExFlags struct {
 	Flags   int32
 	SidMode *SidMode // Argument to swe_set_sid_mode
-	DeltaT  *float64 // Argument to swe_set_delta_t_userdef, nil resets it.
+	DeltaT  *float64 // Argument to swe_set_delta_t_userdef, see SetDeltaT.
 }
 
 // SetDeltaT sets f as delta T in flags object fl.
-// Set fl.DeltaT to nil to reset the value within the Swiss Ephemeris.
+// Set fl.DeltaT to nil to use the ΔT set by SetDeltaTUserDef of the library,
+// by default the internal ΔT model.
 func (fl *AyanamsaExFlags) SetDeltaT(f float64) { fl.DeltaT = &f }
 
 // CalType represents the calendar type used in julian date conversions.
@@ -80,18 +576,20 @@ type DateConvertFlags struct {
 }
 
 // SetDeltaT sets f as delta T in flags object fl.
-// Set fl.DeltaT to nil to reset the value within the Swiss Ephemeris.
+// Set fl.DeltaT to nil to use the ΔT set by SetDeltaTUserDef of the library,
+// by default the internal ΔT model.
 func (fl *DateConvertFlags) SetDeltaT(f float64) { fl.DeltaT = &f }
 
 // HousesExFlags represents library state of swe_houses_ex in a stateless way.
 type HousesExFlags struct {
 	Flags   int32
 	SidMode *SidMode // Argument to swe_set_sid_mode
-	DeltaT  *float64 // Argument to swe_set_delta_t_userdef, nil resets it.
+	DeltaT  *float64 // Argument to swe_set_delta_t_userdef, see SetDeltaT.
 }
 
 // SetDeltaT sets f as delta T in flags object fl.
-// Set fl.DeltaT to nil to reset the value within the Swiss Ephemeris.
+// Set fl.DeltaT to nil to use the ΔT set by SetDeltaTUserDef of the library,
+// by default the internal ΔT model.
 func (fl *HousesExFlags) SetDeltaT(f float64) { fl.DeltaT = &f }
 
 // HSys represents house system identifiers used in the C library.
@@ -117,6 +615,55 @@ func NewHSys(c byte) (hsys HSys, ok bool) {
 	}
 }
 
+// Valid reports whether hsys is a house system implemented in the C library.
+func (hsys HSys) Valid() bool {
+	_, ok := NewHSys(byte(hsys))
+	return ok
+}
+
+// String returns the name of house system hsys, equal to the name returned
+// by HouseName.
+func (hsys HSys) String() string {
+	c, ok := NewHSys(byte(hsys))
+	if !ok {
+		return "HSys(" + strconv.Itoa(int(hsys)) + ")"
+	}
+
+	return hsysNames[c]
+}
+
+var hsysNames = map[HSys]string{
+	'A': "equal",
+	'B': "Alcabitius",
+	'C': "Campanus",
+	'D': "equal (MC)",
+	'E': "equal",
+	'F': "Carter poli-equ.",
+	'G': "Gauquelin sectors",
+	'H': "horizon/azimut",
+	'I': "Sunshine",
+	'i': "Sunshine/alt.",
+	'K': "Koch",
+	'L': "Pullen SD",
+	'M': "Morinus",
+	'N': "equal/1=Aries",
+	'O': "Porphyry",
+	'P': "Placidus",
+	'Q': "Pullen SR",
+	'R': "Regiomontanus",
+	'S': "Sripati",
+	'T': "Polich/Page",
+	'U': "Krusinski-Pisa-Goelzer",
+	'V': "equal/Vehlow",
+	'W': "equal/ whole sign",
+	'X': "axial rotation system/Meridian houses",
+	'Y': "APC houses",
+}
+
+// GauquelinMethod is the type of Gauquelin sector computation methods used by
+// swe_gauquelin_sector.
+type GauquelinMethod int32
+
 // TimeEquFlags represents the library state of swe_time_equ, swe_lmt_to_lat
 // and swe_lat_to_lmt.
 type TimeEquFlags struct {
@@ -124,7 +671,8 @@ type TimeEquFlags struct {
 }
 
 // SetDeltaT sets f as delta T in flags object fl.
-// Set fl.DeltaT to nil to reset the value within the Swiss Ephemeris.
+// Set fl.DeltaT to nil to use the ΔT set by SetDeltaTUserDef of the library,
+// by default the internal ΔT model.
 func (fl *TimeEquFlags) SetDeltaT(f float64) { fl.DeltaT = &f }
 
 // SidTimeFlags represents the library state of swe_sidtime0 and swe_sidtime.
@@ -133,9 +681,78 @@ type SidTimeFlags struct {
 }
 
 // SetDeltaT sets f as delta T in flags object fl.
-// Set fl.DeltaT to nil to reset the value within the Swiss Ephemeris.
+// Set fl.DeltaT to nil to use the ΔT set by SetDeltaTUserDef of the library,
+// by default the internal ΔT model.
 func (fl *SidTimeFlags) SetDeltaT(f float64) { fl.DeltaT = &f }
 
+// EclType is the type of Ecl* constants that describe eclipses and
+// occultations.
+type EclType int32
+
+// AzaltMode is the type of conversion modes used by swe_azalt.
+type AzaltMode int32
+
+// AzaltRevMode is the type of conversion modes used by swe_azalt_rev.
+type AzaltRevMode int32
+
+// AzaltFlags represents the library state of swe_azalt and swe_azalt_rev.
+type AzaltFlags struct {
+	DeltaT *float64
+}
+
+// SetDeltaT sets f as delta T in flags object fl.
+// Set fl.DeltaT to nil to use the ΔT set by SetDeltaTUserDef of the library,
+// by default the internal ΔT model.
+func (fl *AzaltFlags) SetDeltaT(f float64) { fl.DeltaT = &f }
+
+// RefracMode is the type of refraction modes used by swe_refrac and
+// swe_refrac_extended.
+type RefracMode int32
+
+// RiseTransMode is the type of CalcRise, CalcSet, CalcMTransit, CalcITransit
+// and Bit* constants used by swe_rise_trans.
+type RiseTransMode int32
+
+// SplitDegFlags is the type of SplitDeg* constants used by swe_split_deg.
+type SplitDegFlags int32
+
+// HeliacalEvent is the type of heliacal event constants.
+type HeliacalEvent int32
+
+// Atmosphere represents the atmospheric conditions used by swe_heliacal_ut,
+// swe_heliacal_pheno_ut and swe_vis_limit_mag.
+type Atmosphere struct {
+	Press       float64 // atmospheric pressure in millibar
+	Temp        float64 // temperature in degrees Celsius
+	RelHumidity float64 // relative humidity in percent
+	Extinction  float64 // meteorological range in km if >= 1, else extinction coefficient
+}
+
+// Observer represents the observer used by swe_heliacal_ut,
+// swe_heliacal_pheno_ut and swe_vis_limit_mag.
+type Observer struct {
+	Age          float64 // age in years, defaults to 36
+	SnellenRatio float64 // visual acuity, defaults to 1
+
+	// The following fields are only used if HelflagOpticalParams is set.
+	Binocular  bool
+	OpticMagn  float64 // telescope magnification, 1 is naked eye
+	OpticDia   float64 // optical aperture in millimeter
+	OpticTrans float64 // optical transmission
+}
+
+// HeliacalFlags represents the library state of swe_heliacal_ut,
+// swe_heliacal_pheno_ut and swe_vis_limit_mag.
+type HeliacalFlags struct {
+	Flags  int32    // ephemeris flag combined with Helflag* constants
+	DeltaT *float64 // Argument to swe_set_delta_t_userdef, see SetDeltaT.
+}
+
+// SetDeltaT sets f as delta T in flags object fl.
+// Set fl.DeltaT to nil to use the ΔT set by SetDeltaTUserDef of the library,
+// by default the internal ΔT model.
+func (fl *HeliacalFlags) SetDeltaT(f float64) { fl.DeltaT = &f }
+
 // Interface defines a standardized way for interfacing with the Swiss
 // Ephemeris library from Go.
 type Interface interface {
@@ -146,12 +763,53 @@ type Interface interface {
 	PlanetName(pl Planet) (string, error)
 
 	// Calc computes the position and optionally the speed of planet pl at Julian
-	// Date (in Ephemeris Time) et with calculation flags fl.
+	// Date (in Ephemeris Time) et with calculation flags fl. If the C library
+	// reports a warning, for example when it falls back to another ephemeris,
+	// xx is valid and err is a warning, see IsWarning. The orbital elements
+	// of the fictitious bodies FictOffset to FictMax are read from file
+	// seorbel.txt in the ephemeris path, without the file the built-in
+	// elements of Cupido to PlutoPickering are used with a warning. For a
+	// date outside the range of the ephemeris err is a *DateRangeError.
 	Calc(et float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error)
 	// CalcUT computes the position and optionally the speed of planet pl at
 	// Julian Date (in Universal Time) ut with calculation flags fl. Within the C
 	// library swe_deltat is called to convert Universal Time to Ephemeris Time.
+	// Warnings and date range errors are reported like Calc.
 	CalcUT(ut float64, pl Planet, fl *CalcFlags) (xx []float64, cfl int, err error)
+	// CalcPctr computes the position and optionally the speed of planet pl as
+	// seen from center body plctr at Julian Date (in Ephemeris Time) et with
+	// calculation flags fl. The heliocentric, barycentric and topocentric
+	// flags are ignored. Light-time and aberration are computed for an
+	// observer at the center, the gravitational deflection of light is not
+	// applied and FlagNoGDefl is always set in cfl. The lunar nodes and
+	// apsides can't be used for pl or plctr. Warnings are reported like Calc.
+	CalcPctr(et float64, pl, plctr Planet, fl *CalcFlags) (xx []float64, cfl int, err error)
+
+	// FixStar computes the position of fixed star star at Julian Date (in
+	// Ephemeris Time) et with calculation flags fl. The star is looked up by
+	// traditional name, by nomenclature name prefixed with a comma (",alTau")
+	// or by line number in the star file. The returned name is the traditional
+	// name and nomenclature name of the star found, separated by a comma.
+	// Warnings are reported like Calc.
+	FixStar(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error)
+	// FixStarUT computes the position of fixed star star at Julian Date (in
+	// Universal Time) ut with calculation flags fl. The star is looked up by
+	// traditional name, by nomenclature name prefixed with a comma (",alTau")
+	// or by line number in the star file. The returned name is the traditional
+	// name and nomenclature name of the star found, separated by a comma.
+	// Within the C library swe_deltat is called to convert Universal Time to
+	// Ephemeris Time. Warnings are reported like Calc.
+	FixStarUT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error)
+	// FixStar2 is equal to FixStar but looks up the star in an index of the
+	// star file, which is read on first use. It is much faster than FixStar
+	// when computing the positions of many stars, like for a star map.
+	FixStar2(star string, et float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error)
+	// FixStar2UT is equal to FixStarUT but looks up the star in an index of
+	// the star file like FixStar2.
+	FixStar2UT(star string, ut float64, fl *CalcFlags) (name string, xx []float64, cfl int, err error)
+	// FixStarMag returns the visual magnitude of fixed star star. The star is
+	// looked up in the same way as FixStar does.
+	FixStarMag(star string) (name string, mag float64, err error)
 
 	// NodAps computes the positions of planetary nodes and apsides (perihelia,
 	// aphelia, second focal points of the orbital ellipses) for planet pl at
@@ -160,14 +818,102 @@ type Interface interface {
 	NodAps(et float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error)
 	// NodApsUT computes the positions of planetary nodes and apsides (perihelia,
 	// aphelia, second focal points of the orbital ellipses) for planet pl at
-	// Julian Date (in Ephemeris Time) et with calculation flags fl using method
+	// Julian Date (in Universal Time) ut with calculation flags fl using method
 	// m. Within the C library swe_deltat is called to convert Universal Time to
 	// Ephemeris Time.
 	NodApsUT(ut float64, pl Planet, fl *CalcFlags, m NodApsMethod) (nasc, ndsc, peri, aphe []float64, err error)
 
+	// Pheno computes the phase angle, phase, elongation, apparent diameter and
+	// apparent magnitude of planet pl at Julian Date (in Ephemeris Time) et
+	// with calculation flags fl. The values are stored in the first five
+	// elements of attr, see NewPhenoData.
+	Pheno(et float64, pl Planet, fl *CalcFlags) (attr []float64, err error)
+	// PhenoUT computes the phase angle, phase, elongation, apparent diameter
+	// and apparent magnitude of planet pl at Julian Date (in Universal Time) ut
+	// with calculation flags fl. The values are stored in the first five
+	// elements of attr, see NewPhenoData. Within the C library swe_deltat is
+	// called to convert Universal Time to Ephemeris Time.
+	PhenoUT(ut float64, pl Planet, fl *CalcFlags) (attr []float64, err error)
+
+	// OrbitalElements computes the osculating orbital elements of planet pl at
+	// Julian Date (in Ephemeris Time) et with calculation flags fl. Only the
+	// ephemeris flag, FlagHelio, FlagBary, FlagOrbelAA and FlagJ2000 are
+	// used. The elements are stored in the first 17 elements of the returned
+	// slice, see NewOrbitElements.
+	OrbitalElements(et float64, pl Planet, fl *CalcFlags) ([]float64, error)
+
+	// OrbitMaxMinTrueDistance returns the maximum, minimum and true distance
+	// of planet pl at Julian Date (in Ephemeris Time) et with calculation
+	// flags fl. The maximum and minimum distances are derived from the
+	// osculating orbital elements, see OrbitalElements.
+	OrbitMaxMinTrueDistance(et float64, pl Planet, fl *CalcFlags) (dmax, dmin, dtrue float64, err error)
+
+	// SolCross returns the Julian Date (in Ephemeris Time) at which the Sun
+	// crosses ecliptic longitude x2cross. The search starts at Julian Date
+	// (in Ephemeris Time) et and proceeds forward in time, or backward in
+	// time if backward is true. The longitude is computed with calculation
+	// flags fl.
+	SolCross(x2cross, et float64, fl *CalcFlags, backward bool) (float64, error)
+
+	// SolCrossUT returns the Julian Date (in Universal Time) at which the Sun
+	// crosses ecliptic longitude x2cross. The search starts at Julian Date
+	// (in Universal Time) ut and proceeds forward in time, or backward in
+	// time if backward is true. The longitude is computed with calculation
+	// flags fl.
+	SolCrossUT(x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error)
+
+	// MoonCross returns the Julian Date (in Ephemeris Time) at which the Moon
+	// crosses ecliptic longitude x2cross. Unlike SolCross the search always
+	// proceeds forward in time and returns the first crossing within one
+	// lunar orbit after Julian Date (in Ephemeris Time) et. The longitude is
+	// computed with calculation flags fl.
+	MoonCross(x2cross, et float64, fl *CalcFlags) (float64, error)
+
+	// MoonCrossUT returns the Julian Date (in Universal Time) at which the
+	// Moon crosses ecliptic longitude x2cross. Unlike SolCrossUT the search
+	// always proceeds forward in time and returns the first crossing within
+	// one lunar orbit after Julian Date (in Universal Time) ut. The longitude
+	// is computed with calculation flags fl.
+	MoonCrossUT(x2cross, ut float64, fl *CalcFlags) (float64, error)
+
+	// MoonCrossNode returns the first Julian Date (in Ephemeris Time) after
+	// Julian Date (in Ephemeris Time) et at which the Moon crosses one of its
+	// nodes, so when its ecliptic latitude is zero. The ecliptic longitude
+	// and latitude of the Moon at that moment are returned as well. The
+	// position is computed with calculation flags fl.
+	MoonCrossNode(et float64, fl *CalcFlags) (jd, xlon, xlat float64, err error)
+
+	// MoonCrossNodeUT returns the first Julian Date (in Universal Time) after
+	// Julian Date (in Universal Time) ut at which the Moon crosses one of its
+	// nodes, so when its ecliptic latitude is zero. The ecliptic longitude
+	// and latitude of the Moon at that moment are returned as well. The
+	// position is computed with calculation flags fl.
+	MoonCrossNodeUT(ut float64, fl *CalcFlags) (jd, xlon, xlat float64, err error)
+
+	// HelioCross returns the Julian Date (in Ephemeris Time) at which planet
+	// pl crosses heliocentric ecliptic longitude x2cross. The search starts at
+	// Julian Date (in Ephemeris Time) et and proceeds forward in time, or
+	// backward in time if backward is true. The longitude is computed with
+	// calculation flags fl, FlagHelio is always set. An error is returned for
+	// the Sun, the Moon, the lunar nodes and apsides.
+	HelioCross(pl Planet, x2cross, et float64, fl *CalcFlags, backward bool) (float64, error)
+
+	// HelioCrossUT returns the Julian Date (in Universal Time) at which planet
+	// pl crosses heliocentric ecliptic longitude x2cross. The search starts at
+	// Julian Date (in Universal Time) ut and proceeds forward in time, or
+	// backward in time if backward is true. The longitude is computed with
+	// calculation flags fl, FlagHelio is always set. An error is returned for
+	// the Sun, the Moon, the lunar nodes and apsides.
+	HelioCrossUT(pl Planet, x2cross, ut float64, fl *CalcFlags, backward bool) (float64, error)
+
 	// GetAyanamsaEx returns the ayanamsa for Julian Date (in Ephemeris Time) et.
 	// It is equal to GetAyanamsa but uses the ΔT consistent with the ephemeris
-	// passed in fl.Flags.
+	// passed in fl.Flags. The returned value is the mean ayanamsa, the
+	// ayanamsa at the reference date of the sidereal mode plus precession. It
+	// does not include nutation, even if FlagNoNut is not set. The sidereal
+	// longitudes returned by Calc are relative to the true ayanamsa: the mean
+	// ayanamsa plus the nutation in longitude returned by Calc for EclNut,
+	// unless FlagNoNut is set.
 	GetAyanamsaEx(et float64, fl *AyanamsaExFlags) (float64, error)
 	// GetAyanamsaExUT returns the ayanamsa for Julian Date (in Universal Time) ut.
 	// It is equal to GetAyanamsaUT but uses the ΔT consistent with the ephemeris
@@ -179,6 +925,11 @@ type Interface interface {
 	// JulDay returns the corresponding Julian Date for the given date.
 	// Calendar type ct is used to clearify the year y, Julian or Gregorian.
 	JulDay(y, m, d int, h float64, ct CalType) (float64, error)
+	// DateConversion returns the corresponding Julian Date for the given date
+	// like JulDay, but returns ErrInvalidDate if the date is not valid in
+	// calendar type ct. The returned Julian Date is the normalized date in
+	// that case, that is 30 February is treated as 1 or 2 March.
+	DateConversion(y, m, d int, h float64, ct CalType) (float64, error)
 	// RevJul returns the corresponding calendar date for the given Julian Date.
 	// Calendar type ct is used to clearify the year y, Julian or Gregorian.
 	RevJul(jd float64, ct CalType) (y, m, d int, h float64, err error)
@@ -191,28 +942,52 @@ type Interface interface {
 	// JdETToUTC returns the corresponding calendar date for the given Julian
 	// Date in Universal Time and accounts for leap seconds in the conversion.
 	JdUT1ToUTC(ut1 float64, fl *DateConvertFlags) (y, m, d, h, i int, s float64, err error)
+	// DayOfWeek returns the day of week for the given Julian Date. Contrary to
+	// time.Weekday the week starts on Monday, so 0 is Monday and 6 is Sunday.
+	DayOfWeek(jd float64) (int, error)
 
 	// HousesEx returns the house cusps and related positions for the given
 	// geographic location using the given house system and the provided flags
 	// (reference frame). The return values may contain data in case of an error.
-	// Geolat and geolon are in degrees.
-	HousesEx(ut float64, fl *HousesExFlags, geolat, geolon float64, hsys HSys) ([]float64, []float64, error)
-	// HousesArmc returns the house cusps and related positions for the given
+	// If the house system is not defined at the latitude the Porphyry cusps are
+	// returned with warning ErrHouseFallback. The latitude and longitude of geo
+	// are in degrees, the altitude is not used. Use NewHousesResult to access
+	// the return values by name.
+	HousesEx(ut float64, fl *HousesExFlags, geo *GeoLoc, hsys HSys) ([]float64, []float64, error)
+	// HousesARMC returns the house cusps and related positions for the given
 	// geographic location using the given house system, ecliptic obliquity and
 	// ARMC (also known as RAMC). The return values may contain data in case of
-	// an error. ARMC, geolat, geolon and eps are in degrees.
+	// an error, see HousesEx for ErrHouseFallback. ARMC, geolat and eps are in
+	// degrees. Use NewHousesResult to access the return values by name.
 	HousesARMC(armc, geolat, eps float64, hsys HSys) ([]float64, []float64, error)
 	// HousePos returns the house position for the ecliptic longitude and
 	// latitude of a planet for a given ARMC (also known as RAMC) and geocentric
 	// latitude using the given house system. ARMC, geolat, eps, pllng and pllat
 	// are in degrees.
 	// Before calling HousePos either Houses, HousesEx or HousesARMC should be
-	// called first.
+	// called first. The position is in the range 1 <= pos < 13, or
+	// 1 <= pos < 37 for the Gauquelin house system. An invalid house system
+	// returns an error.
 	HousePos(armc, geolat, eps float64, hsys HSys, pllng, pllat float64) (float64, error)
 	// HouseName returns the name of the house system.
 	HouseName(hsys HSys) (string, error)
+	// GauquelinSector returns the Gauquelin sector position of planet pl or
+	// fixed star star at Julian Date (in Universal Time) ut for geographic
+	// location geo, computed using method m. Atmospheric pressure press (in
+	// millibar) and temperature temp (in degrees Celsius) are only used by the
+	// methods that account for refraction. If star is not empty, pl is
+	// ignored. The returned sector position is a continuous value in the range
+	// 1 <= pos < 37.
+	GauquelinSector(ut float64, pl Planet, star string, fl *CalcFlags, m GauquelinMethod, geo *GeoLoc, press, temp float64) (float64, error)
 
-	// DeltaTEx returns the ΔT for the Julian Date jd.
+	// DeltaTEx returns the ΔT for the Julian Date jd. ΔT is computed with the
+	// tidal acceleration of the Moon consistent with ephemeris eph, one of JPL,
+	// Swiss, Moshier and DefaultEph as passed to SetEphemeris, unless the
+	// tidal acceleration is set explicitly on the implementation (for example
+	// via SetTidAcc of swecgo.Library), which then applies to all ephemerides.
+	// Functions that compute ΔT implicitly, such as CalcUT, use the ephemeris
+	// of their flags. If the files of ephemeris eph are missing, ΔT is
+	// computed for the fallback ephemeris and err is a warning.
 	DeltaTEx(jd float64, eph Ephemeris) (float64, error)
 
 	// TimeEqu returns the difference between local apparent and local mean time
@@ -226,11 +1001,173 @@ type Interface interface {
 	LATToLMT(jdLAT, geolon float64, fl *TimeEquFlags) (float64, error)
 
 	// SidTime0 returns the sidereal time for Julian Date jd, ecliptic obliquity
-	// eps and nutation nut at the Greenwich medidian, measured in hours.
+	// eps and nutation nut at the Greenwich medidian, measured in hours. Both
+	// eps, the true obliquity, and nut, the nutation in longitude, are in
+	// degrees, as returned by Obliquity.
 	SidTime0(ut, eps, nut float64, fl *SidTimeFlags) (float64, error)
 	// SidTime returns the sidereal time for Julian Date jd at the Greenwich
 	// medidian, measured in hours.
 	SidTime(ut float64, fl *SidTimeFlags) (float64, error)
+
+	// Azalt converts the ecliptic or equatorial position in of a body at
+	// Julian Date (in Universal Time) ut to horizontal coordinates as seen from
+	// geographic location geo. Conversion mode m selects whether in holds
+	// ecliptic or equatorial coordinates. Atmospheric pressure press (in
+	// millibar) and temperature temp (in degrees Celsius) are used to compute
+	// the apparent altitude. If press is 0, the pressure is estimated from the
+	// altitude of geo. The returned slice contains the azimuth, true altitude
+	// and apparent altitude in degrees.
+	Azalt(ut float64, m AzaltMode, geo *GeoLoc, press, temp float64, in [3]float64, fl *AzaltFlags) ([]float64, error)
+	// AzaltRev converts the horizontal position in (azimuth and true altitude)
+	// of a body at Julian Date (in Universal Time) ut as seen from geographic
+	// location geo to either ecliptic or equatorial coordinates, selected by
+	// conversion mode m.
+	AzaltRev(ut float64, m AzaltRevMode, geo *GeoLoc, in [2]float64, fl *AzaltFlags) ([]float64, error)
+	// Refrac converts the true altitude alt to the apparent altitude or the
+	// other way around, selected by refraction mode m. Atmospheric pressure
+	// press is in millibar and temperature temp in degrees Celsius.
+	Refrac(alt, press, temp float64, m RefracMode) (float64, error)
+	// RefracExtended is equal to Refrac but also accounts for the altitude of
+	// the observer geoalt (in meters) and the atmospheric lapse rate (in
+	// kelvin per meter). The returned slice contains the true altitude,
+	// apparent altitude, refraction and dip of the horizon in degrees.
+	RefracExtended(alt, geoalt, press, temp, lapseRate float64, m RefracMode) (float64, []float64, error)
+
+	// RiseTrans computes the time of rising, setting or meridian transit of
+	// planet pl or fixed star star, starting at Julian Date (in Universal Time)
+	// ut, as seen from geographic location geo. Event m selects which event is
+	// searched for and can be combined with the Bit* modifiers. Atmospheric
+	// pressure press (in millibar) and temperature temp (in degrees Celsius)
+	// are used to compute the refraction. If star is not empty, pl is ignored.
+	// If the body does not rise or set at the location, ErrNoRiseSet is
+	// returned.
+	RiseTrans(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp float64) (float64, error)
+	// RiseTransTrueHor is equal to RiseTrans but computes the rising and
+	// setting relative to a local horizon with altitude horhgt (in degrees)
+	// instead of the mathematical horizon.
+	RiseTransTrueHor(ut float64, pl Planet, star string, fl *CalcFlags, m RiseTransMode, geo *GeoLoc, press, temp, horhgt float64) (float64, error)
+
+	// HeliacalUT searches for the heliacal event ev of object obj starting at
+	// Julian Date (in Universal Time) ut, as seen by observer obs from
+	// geographic location geo under atmospheric conditions atm. Object obj is
+	// either a planet name ("venus", "moon") or a fixed star name. Zero values
+	// in atm and obs are replaced by defaults within the C library. The first
+	// three elements of the returned slice contain the Julian Date (in
+	// Universal Time) of the start, optimum and end of visibility.
+	HeliacalUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error)
+	// HeliacalPhenoUT computes the visibility phenomena of object obj for
+	// heliacal event ev at Julian Date (in Universal Time) ut, as seen by
+	// observer obs from geographic location geo under atmospheric conditions
+	// atm. The returned slice is indexed by the Hel* constants.
+	HeliacalPhenoUT(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, ev HeliacalEvent, fl *HeliacalFlags) ([]float64, error)
+	// VisLimitMag computes the limiting visual magnitude for object obj at
+	// Julian Date (in Universal Time) ut, as seen by observer obs from
+	// geographic location geo under atmospheric conditions atm. The returned
+	// slice contains the limiting magnitude, the altitude and azimuth of the
+	// object, sun and moon and the magnitude of the object. If the object is
+	// below the local horizon, visible is false and only the first element of
+	// the slice is set (to -100).
+	VisLimitMag(ut float64, geo *GeoLoc, atm *Atmosphere, obs *Observer, obj string, fl *HeliacalFlags) (dret []float64, visible bool, err error)
+
+	// SolEclipseWhere computes the geographic location of the central line of
+	// a solar eclipse at Julian Date (in Universal Time) ut and the attributes
+	// of the eclipse at that location. The first two elements of the returned
+	// geo slice contain the longitude and latitude of the central line, the
+	// remaining elements the limits of the umbra and penumbra. The eclipse
+	// type is 0 if there is no solar eclipse at ut.
+	SolEclipseWhere(ut float64, fl *CalcFlags) (geo, attr []float64, typ EclType, err error)
+	// SolEclipseHow computes the attributes of a solar eclipse at Julian Date
+	// (in Universal Time) ut as seen from geographic location geo. The
+	// attributes are the magnitude, ratio of the lunar and solar diameter,
+	// obscuration, diameter of the core shadow in km, azimuth, true and
+	// apparent altitude of the sun, elongation of the moon, NASA magnitude and
+	// the saros series number and member. The eclipse type is 0 if no eclipse
+	// is visible at geo.
+	SolEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error)
+	// SolEclipseWhenGlob searches for the next solar eclipse of type typ
+	// globally, starting at Julian Date (in Universal Time) ut. If backward is
+	// true the search is done backward in time. The returned slice contains the
+	// Julian Dates (in Universal Time) of the maximum eclipse, the eclipse at
+	// local apparent noon, eclipse begin and end, totality begin and end,
+	// center line begin and end and the times when an annular-total eclipse
+	// becomes total and annular again. Pass 0 as typ to search for any type.
+	SolEclipseWhenGlob(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error)
+	// SolEclipseWhenLoc searches for the next solar eclipse visible from
+	// geographic location geo, starting at Julian Date (in Universal Time) ut.
+	// If backward is true the search is done backward in time. The returned
+	// tret slice contains the Julian Dates (in Universal Time) of the maximum
+	// eclipse, the first to fourth contact and sunrise and sunset between the
+	// first and fourth contact. The attributes in attr are equal to those of
+	// SolEclipseHow.
+	SolEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error)
+
+	// LunEclipseHow computes the attributes of a lunar eclipse at Julian Date
+	// (in Universal Time) ut as seen from geographic location geo. The
+	// attributes are the umbral and penumbral magnitude, azimuth, true and
+	// apparent altitude of the moon (elements 4 to 6), distance of the moon
+	// from opposition and the saros series number and member (elements 9 and
+	// 10). The eclipse type is 0 if there is no lunar eclipse at ut.
+	LunEclipseHow(ut float64, fl *CalcFlags, geo *GeoLoc) (attr []float64, typ EclType, err error)
+	// LunEclipseWhen searches for the next lunar eclipse of type typ, starting
+	// at Julian Date (in Universal Time) ut. If backward is true the search is
+	// done backward in time. The returned slice contains the Julian Dates (in
+	// Universal Time) of the maximum eclipse, partial phase begin and end
+	// (elements 2 and 3), totality begin and end and penumbral phase begin and
+	// end. Pass 0 as typ to search for any type.
+	LunEclipseWhen(ut float64, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error)
+	// LunEclipseWhenLoc searches for the next lunar eclipse visible from
+	// geographic location geo, starting at Julian Date (in Universal Time) ut.
+	// If backward is true the search is done backward in time. The returned
+	// tret slice is equal to that of LunEclipseWhen, extended by moonrise and
+	// moonset if they occur during the eclipse. The attributes in attr are
+	// equal to those of LunEclipseHow.
+	LunEclipseWhenLoc(ut float64, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error)
+
+	// LunOccultWhere computes the geographic location of the central line of
+	// an occultation of planet pl or fixed star star by the moon at Julian
+	// Date (in Universal Time) ut. The returned slices are equal to those of
+	// SolEclipseWhere. If star is not empty, pl is ignored.
+	LunOccultWhere(ut float64, pl Planet, star string, fl *CalcFlags) (geo, attr []float64, typ EclType, err error)
+	// LunOccultWhenGlob searches for the next occultation of type typ of
+	// planet pl or fixed star star by the moon globally, starting at Julian
+	// Date (in Universal Time) ut. If backward is true the search is done
+	// backward in time. If typ contains EclOneTry, only the next conjunction of
+	// the moon with the body is checked. The returned slice is equal to that of
+	// SolEclipseWhenGlob. If star is not empty, pl is ignored.
+	LunOccultWhenGlob(ut float64, pl Planet, star string, fl *CalcFlags, typ EclType, backward bool) (tret []float64, rtyp EclType, err error)
+	// LunOccultWhenLoc searches for the next occultation of planet pl or fixed
+	// star star by the moon visible from geographic location geo, starting at
+	// Julian Date (in Universal Time) ut. If backward is true the search is
+	// done backward in time. The returned slices are equal to those of
+	// SolEclipseWhenLoc. If star is not empty, pl is ignored.
+	LunOccultWhenLoc(ut float64, pl Planet, star string, fl *CalcFlags, geo *GeoLoc, backward bool) (tret, attr []float64, typ EclType, err error)
+
+	// Cotrans rotates the polar coordinates in (longitude, latitude and
+	// distance) by the ecliptic obliquity eps (in degrees). A positive eps
+	// converts equatorial to ecliptic coordinates, a negative eps ecliptic to
+	// equatorial coordinates. Cotrans does not depend on library state and is
+	// safe for concurrent use.
+	Cotrans(in [3]float64, eps float64) ([]float64, error)
+	// CotransSp is equal to Cotrans but also rotates the speed values stored
+	// in the last three elements of in.
+	CotransSp(in [6]float64, eps float64) ([]float64, error)
+
+	// DegNorm normalizes angle x (in degrees) to the range 0 <= x < 360.
+	DegNorm(x float64) (float64, error)
+	// RadNorm normalizes angle x (in radians) to the range 0 <= x < 2π.
+	RadNorm(x float64) (float64, error)
+	// DegMidp returns the midpoint of angles x1 and x0 (in degrees). The
+	// midpoint is taken on the shorter arc between both angles.
+	DegMidp(x1, x0 float64) (float64, error)
+	// RadMidp returns the midpoint of angles x1 and x0 (in radians). The
+	// midpoint is taken on the shorter arc between both angles.
+	RadMidp(x1, x0 float64) (float64, error)
+	// SplitDeg splits angle x (in degrees) into degrees, minutes, seconds and
+	// the fraction of the second, rounded as selected by fl. The fraction is
+	// only set if no rounding is requested. If SplitDegZodiacal is passed in fl,
+	// sign contains the zodiac sign index (0 to 11) and deg the degrees within
+	// the sign, otherwise sign contains the sign of x (1 or -1).
+	SplitDeg(x float64, fl SplitDegFlags) (deg, min, sec int, secfr float64, sign int, err error)
 }
 
 // Locked tries to exclusively lock the library handle, disable per function
@@ -268,3 +1205,9 @@ type LockedInterface interface {
 	Interface
 	ExclusiveUnlock()
 }
+
+// nopLocked is the LockedInterface of a handle that does not implement
+// ExclusiveLocker, as used by the handles wrapping another handle.
+type nopLocked struct{ Interface }
+
+func (nopLocked) ExclusiveUnlock() {}
@@ -1,6 +1,58 @@
 package swego
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIsWarning(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("no swisseph error"), false},
+		{Error{Code: -1, Message: "error"}, false},
+		{Error{Message: "zero code"}, false},
+		{Error{Code: 2, Message: "warning", Warning: true}, true},
+		{fmt.Errorf("calc: %w", Error{Code: 0, Message: "warning", Warning: true}), true},
+	}
+
+	for _, c := range cases {
+		if got := IsWarning(c.err); got != c.want {
+			t.Errorf("IsWarning(%v) = %t, want: %t", c.err, got, c.want)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	err := fmt.Errorf("calc: %w", Error{Code: -1, Message: "star name empty"})
+
+	var e Error
+	if !errors.As(err, &e) {
+		t.Fatalf("errors.As(%v) = false, want: true", err)
+	}
+
+	if e.Code != -1 {
+		t.Errorf("code = %d, want: -1", e.Code)
+	}
+
+	if e.Message != "star name empty" {
+		t.Errorf("message = %q, want: \"star name empty\"", e.Message)
+	}
+
+	if want := "swisseph: star name empty"; e.Error() != want {
+		t.Errorf("Error() = %q, want: %q", e.Error(), want)
+	}
+}
 
 func TestNewHSys(t *testing.T) {
 	cases := []struct {
@@ -65,6 +117,35 @@ func TestNewHSys_lowerToUpper(t *testing.T) {
 	}
 }
 
+func TestHSys_Valid(t *testing.T) {
+	if !Placidus.Valid() {
+		t.Error("Placidus.Valid() = false, want: true")
+	}
+
+	if HSys('Z').Valid() {
+		t.Error("HSys('Z').Valid() = true, want: false")
+	}
+}
+
+func TestHSys_String(t *testing.T) {
+	cases := []struct {
+		in   HSys
+		want string
+	}{
+		{Placidus, "Placidus"},
+		{Koch, "Koch"},
+		{SunshineAlt, "Sunshine/alt."},
+		{HSys('w'), "equal/ whole sign"},
+		{HSys('Z'), "HSys(90)"},
+	}
+
+	for _, c := range cases {
+		if got := c.in.String(); got != c.want {
+			t.Errorf("%q.String() = %q, want: %q", byte(c.in), got, c.want)
+		}
+	}
+}
+
 func TestCalcFlags_Copy(t *testing.T) {
 	fl := new(CalcFlags)
 	fl.Flags = FlagSpeed
@@ -84,6 +165,28 @@ func TestCalcFlags_SetEphemeris(t *testing.T) {
 	}
 }
 
+func TestNewCalcFlags(t *testing.T) {
+	got := NewCalcFlags().
+		Speed().
+		Topocentric(47.37, 8.55, 400).
+		Sidereal(SidmLahiri).
+		Ephemeris(JPL).
+		Ephemeris(Moshier)
+
+	want := int32(FlagSpeed | FlagTopo | FlagSidereal | FlagEphMoshier)
+	if got.Flags != want {
+		t.Errorf("flags = %d, want: %d", got.Flags, want)
+	}
+
+	if loc := (GeoLoc{Long: 8.55, Lat: 47.37, Alt: 400}); *got.TopoLoc != loc {
+		t.Errorf("topo loc = %v, want: %v", *got.TopoLoc, loc)
+	}
+
+	if got.SidMode.Mode != SidmLahiri {
+		t.Errorf("sid mode = %d, want: %d", got.SidMode.Mode, SidmLahiri)
+	}
+}
+
 type testInterface struct{ Interface }
 type testExclLocker struct{ Interface }
 type testLockedIface struct{ Interface }
@@ -93,6 +196,177 @@ func (el *testExclLocker) ExclusiveLock() LockedInterface {
 	return &testLockedIface{el}
 }
 
+func TestCalcFlags_String(t *testing.T) {
+	dt := 0.5
+
+	cases := []struct {
+		in   *CalcFlags
+		want string
+	}{
+		{nil, "<nil>"},
+		{&CalcFlags{}, "0"},
+		{NewCalcFlags().Speed().Ephemeris(Swiss).Topocentric(52.5, 13.4, 34),
+			"SEFLG_SWIEPH|SEFLG_SPEED|SEFLG_TOPOCTR|TOPO(52.5,13.4,34)"},
+		{NewCalcFlags().Sidereal(SidmLahiri), "SEFLG_SIDEREAL|SID(1,0,0)"},
+		{&CalcFlags{Flags: FlagEphJPL | 1<<7, JPLFile: "de431.eph", DeltaT: &dt},
+			"SEFLG_JPLEPH|0x80|JPL(de431.eph)|DELTAT(0.5)"},
+	}
+
+	for _, c := range cases {
+		if got := c.in.String(); got != c.want {
+			t.Errorf("String() = %q, want: %q", got, c.want)
+		}
+	}
+}
+
+func TestNewPhenoData(t *testing.T) {
+	attr := make([]float64, 20)
+	attr[0], attr[1], attr[2], attr[3], attr[4] = 1, 2, 3, 4, 5
+
+	got := NewPhenoData(attr)
+	want := PhenoData{
+		PhaseAngle:        1,
+		Phase:             2,
+		Elongation:        3,
+		ApparentDiameter:  4,
+		ApparentMagnitude: 5,
+	}
+
+	if got != want {
+		t.Errorf("NewPhenoData(%v) = %v, want: %v", attr, got, want)
+	}
+}
+
+func TestNewOrbitElements(t *testing.T) {
+	dret := make([]float64, 50)
+	for i := 0; i < 17; i++ {
+		dret[i] = float64(i + 1)
+	}
+
+	got := NewOrbitElements(dret)
+	want := OrbitElements{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}
+
+	if got != want {
+		t.Errorf("NewOrbitElements(%v) = %v, want: %v", dret, got, want)
+	}
+}
+
+func TestNewHousesResult(t *testing.T) {
+	cusps := make([]float64, 13)
+	ascmc := make([]float64, 10)
+	for i := range ascmc {
+		ascmc[i] = float64(i + 1)
+	}
+
+	got := NewHousesResult(cusps, ascmc)
+	want := HousesResult{
+		Cusps:               cusps,
+		Ascendant:           1,
+		MC:                  2,
+		ARMC:                3,
+		Vertex:              4,
+		EquatorialAscendant: 5,
+		CoAscKoch:           6,
+		CoAscMunkasey:       7,
+		PolarAscendant:      8,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewHousesResult(%v, %v) = %v, want: %v", cusps, ascmc, got, want)
+	}
+}
+
+func TestNewHousesResult_gauquelin(t *testing.T) {
+	cusps := make([]float64, 37)
+	for i := 1; i < len(cusps); i++ {
+		cusps[i] = float64(360 - (i-1)*10)
+	}
+
+	res := NewHousesResult(cusps, make([]float64, 10))
+	if len(res.Cusps) != 37 || res.Cusps[36] != 10 {
+		t.Fatalf("NewHousesResult() cusps = %v, want 36 sectors", res.Cusps)
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got HousesResult
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, res) {
+		t.Errorf("json round trip = %v, want: %v", got, res)
+	}
+}
+
+func TestHousePosEcl(t *testing.T) {
+	// equal houses with the ascendant at 100°
+	cusps := make([]float64, 13)
+	for i := 1; i <= 12; i++ {
+		cusps[i] = math.Mod(100+float64(i-1)*30, 360)
+	}
+
+	cases := []struct {
+		xlon, want float64
+	}{
+		{100, 1},
+		{115, 1.5},
+		{130, 2},
+		{349, 9.3},
+		{10, 10},
+		{99.999999, 12.999999 + 2.0/3*1e-6},
+		{459, 12.966667},
+		{-261, 12.966667},
+	}
+
+	for _, c := range cases {
+		if got := HousePosEcl(cusps, c.xlon); math.Abs(got-c.want) > 1e-6 {
+			t.Errorf("HousePosEcl(%v, %f) = %f, want: %f", cusps, c.xlon, got, c.want)
+		}
+	}
+}
+
+func TestLongitudeFromHousePos(t *testing.T) {
+	cusps := []float64{0, 350, 20, 50, 80, 110, 140, 170, 200, 230, 260, 290, 320}
+
+	cases := []struct {
+		hsys HSys
+		pos  float64
+		want float64
+	}{
+		{Equal, 1, 350},
+		{Equal, 1.5, 5},
+		{Equal, 5.5, 125},
+		{Equal, 12.75, 342.5},
+		{WholeSign, 7.25, 177.5},
+		{Porphyrius, 10, 260},
+	}
+
+	for _, c := range cases {
+		got, err := LongitudeFromHousePos(cusps, c.hsys, c.pos)
+		if err != nil || math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("LongitudeFromHousePos(%c, %g) = (%g, %v), want: %g", c.hsys, c.pos, got, err, c.want)
+		}
+
+		if pos := HousePosEcl(cusps, got); math.Abs(pos-c.pos) > 1e-9 {
+			t.Errorf("HousePosEcl(%g) = %g, want: %g", got, pos, c.pos)
+		}
+	}
+
+	for _, pos := range []float64{0.5, 13, math.NaN()} {
+		if _, err := LongitudeFromHousePos(cusps, Equal, pos); err == nil {
+			t.Errorf("LongitudeFromHousePos(%g) err = nil, want error", pos)
+		}
+	}
+
+	if _, err := LongitudeFromHousePos(cusps, Placidus, 2); err == nil {
+		t.Error("LongitudeFromHousePos(Placidus) err = nil, want error")
+	}
+}
+
 func TestLocked(t *testing.T) {
 	t.Run("Interface", func(t *testing.T) {
 		called := make(chan struct{}, 1)
@@ -130,3 +404,1222 @@ func TestLocked(t *testing.T) {
 		}
 	})
 }
+
+type testCrossIface struct {
+	Interface
+	called bool
+}
+
+func (ti *testCrossIface) SolCross(x2cross, et float64, fl *CalcFlags, backward bool) (float64, error) {
+	ti.called = true
+	return et, nil
+}
+
+func TestWithContext(t *testing.T) {
+	t.Run("Active", func(t *testing.T) {
+		inner := new(testCrossIface)
+		swe := WithContext(context.Background(), inner)
+
+		got, err := swe.SolCross(0, 2451545.0, nil, false)
+		if err != nil {
+			t.Fatalf("SolCross() err = %q", err)
+		}
+
+		if !inner.called || got != 2451545.0 {
+			t.Errorf("SolCross() = %f, called: %t, want: 2451545.0, called: true",
+				got, inner.called)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		inner := new(testCrossIface)
+		swe := WithContext(ctx, inner)
+
+		if _, err := swe.SolCross(0, 2451545.0, nil, false); err != context.Canceled {
+			t.Errorf("SolCross() err = %v, want: %v", err, context.Canceled)
+		}
+
+		if inner.called {
+			t.Error("inner SolCross called after cancellation")
+		}
+	})
+}
+
+type testCancelIface struct {
+	Interface
+	cancel func()
+	calls  int
+}
+
+func (ti *testCancelIface) CalcUT(ut float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	ti.calls++
+	if ti.calls == 10 {
+		ti.cancel()
+	}
+
+	return make([]float64, 6), 0, nil
+}
+
+func TestWithContext_search(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	inner := &testCancelIface{cancel: cancel}
+	swe := WithContext(ctx, inner)
+
+	// The longitudes never form the aspect, without cancellation the search
+	// runs for the maximum searched period.
+	if _, err := NextAspect(swe, 0, Sun, Sun, 90, nil); err != context.Canceled {
+		t.Errorf("NextAspect() err = %v, want: %v", err, context.Canceled)
+	}
+
+	if inner.calls != 10 {
+		t.Errorf("CalcUT calls = %d, want: 10", inner.calls)
+	}
+}
+
+func TestWithContext_locked(t *testing.T) {
+	inner := &testDeltaTLocker{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	Locked(WithContext(ctx, inner), func(swe Interface) {
+		if !inner.locked {
+			t.Error("underlying handle not locked")
+		}
+
+		if _, err := swe.SolCross(0, 2451545.0, nil, false); err != context.Canceled {
+			t.Errorf("SolCross() err = %v, want: %v", err, context.Canceled)
+		}
+	})
+
+	if inner.locked {
+		t.Error("underlying handle not unlocked")
+	}
+}
+
+type testCountIface struct {
+	Interface
+	n int
+}
+
+func (ti *testCountIface) Calc(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	ti.n++ // unguarded, reported by the race detector if not serialized
+	return make([]float64, 6), 0, nil
+}
+
+func TestSerialized(t *testing.T) {
+	inner := new(testCountIface)
+	swe := Serialized(inner)
+
+	const goroutines, calls = 8, 100
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < calls; j++ {
+				if _, _, err := swe.Calc(2451545.0, Sun, nil); err != nil {
+					t.Errorf("Calc() err = %q", err)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if inner.n != goroutines*calls {
+		t.Errorf("calls = %d, want: %d", inner.n, goroutines*calls)
+	}
+
+	Locked(swe, func(swe Interface) {
+		if _, _, err := swe.Calc(2451545.0, Sun, nil); err != nil {
+			t.Errorf("Calc() err = %q", err)
+		}
+	})
+
+	if inner.n != goroutines*calls+1 {
+		t.Errorf("calls = %d, want: %d", inner.n, goroutines*calls+1)
+	}
+}
+
+type testPoolIface struct {
+	Interface
+	calls   chan struct{}
+	path    string
+	closed  bool
+	release chan struct{}
+}
+
+func (ti *testPoolIface) Version() (string, error) {
+	ti.calls <- struct{}{}
+	<-ti.release
+	return ti.path, nil
+}
+
+func (ti *testPoolIface) Close() { ti.closed = true }
+
+func TestPool(t *testing.T) {
+	const size = 3
+
+	calls := make(chan struct{}, size)
+	release := make(chan struct{})
+
+	var handles []*testPoolIface
+	p := NewPool(size, func() Interface {
+		h := &testPoolIface{calls: calls, release: release}
+		handles = append(handles, h)
+		return h
+	})
+
+	err := p.Broadcast(func(swe Interface) error {
+		swe.(*testPoolIface).path = "path"
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Broadcast() err = %q", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(size)
+
+	for i := 0; i < size; i++ {
+		go func() {
+			defer wg.Done()
+
+			if v, err := p.Version(); err != nil || v != "path" {
+				t.Errorf("Version() = %q, %v, want: path, <nil>", v, err)
+			}
+		}()
+	}
+
+	// all handles are used in parallel
+	for i := 0; i < size; i++ {
+		<-calls
+	}
+
+	close(release)
+	wg.Wait()
+	p.Close()
+
+	for i, h := range handles {
+		if !h.closed {
+			t.Errorf("handle %d not closed", i)
+		}
+	}
+
+	if _, err := p.Version(); err != ErrPoolClosed {
+		t.Errorf("Version() err = %v, want: %v", err, ErrPoolClosed)
+	}
+
+	if err := p.Broadcast(nil); err != ErrPoolClosed {
+		t.Errorf("Broadcast() err = %v, want: %v", err, ErrPoolClosed)
+	}
+}
+
+type testPathIface struct {
+	*Fake
+	path string
+}
+
+func (ti *testPathIface) SetPath(path string) { ti.path = path }
+
+func TestPool_locked(t *testing.T) {
+	var handles []*testPathIface
+	p := NewPool(2, func() Interface {
+		h := &testPathIface{Fake: &Fake{}}
+		id := strconv.Itoa(len(handles))
+		h.VersionFunc = func() (string, error) { return id, nil }
+		handles = append(handles, h)
+		return h
+	})
+
+	defer p.Close()
+
+	if err := p.SetPath("ephe"); err != nil {
+		t.Fatalf("SetPath() err = %v", err)
+	}
+
+	for i, h := range handles {
+		if h.path != "ephe" {
+			t.Errorf("handle %d path = %q, want: ephe", i, h.path)
+		}
+	}
+
+	Locked(p, func(swe Interface) {
+		first, _ := swe.Version()
+		for i := 0; i < 10; i++ {
+			if v, _ := swe.Version(); v != first {
+				t.Errorf("Version() = %q, want: %q", v, first)
+			}
+
+			// the other worker serves the calls to the pool
+			if v, _ := p.Version(); v == first {
+				t.Errorf("pool Version() = %q, want the other handle", v)
+			}
+		}
+	})
+
+	func() {
+		defer func() {
+			if r := recover(); r != "broadcast" {
+				t.Errorf("recover() = %v, want: broadcast", r)
+			}
+		}()
+
+		p.Broadcast(func(swe Interface) error { panic("broadcast") })
+	}()
+
+	// the handles are returned to the pool after the panic
+	if _, err := p.Version(); err != nil {
+		t.Errorf("Version() err = %v", err)
+	}
+}
+
+type testCalcIface struct{ Interface }
+
+func (testCalcIface) Calc(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	switch pl {
+	case Moon:
+		return []float64{et, 1, 1, 0, 0, 0}, 0, Error{Code: 0, Message: "warning", Warning: true}
+	case Mars:
+		return nil, -1, Error{Code: -1, Message: "error"}
+	}
+
+	return []float64{et, float64(pl), 1, 0, 0, 0}, 0, nil
+}
+
+func TestCalcAll(t *testing.T) {
+	swe := testCalcIface{}
+
+	t.Run("Warning", func(t *testing.T) {
+		got, err := CalcAll(swe, 2451545.0, []Planet{Sun, Moon, Mercury}, nil)
+		if err != nil {
+			t.Fatalf("CalcAll() err = %q", err)
+		}
+
+		if len(got) != 3 {
+			t.Fatalf("len(CalcAll()) = %d, want: 3", len(got))
+		}
+
+		for i, pl := range []Planet{Sun, Moon, Mercury} {
+			if got[i].Planet != pl || got[i].XX[0] != 2451545.0 {
+				t.Errorf("result %d = %v, want planet %d at 2451545.0", i, got[i], pl)
+			}
+		}
+
+		if !IsWarning(got[1].Warning) {
+			t.Errorf("result 1 warning = %v, want a warning", got[1].Warning)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		got, err := CalcAll(swe, 2451545.0, []Planet{Sun, Mars, Mercury}, nil)
+		if (err != Error{Code: -1, Message: "error"}) {
+			t.Errorf("CalcAll() err = %v, want: error", err)
+		}
+
+		if len(got) != 1 || got[0].Planet != Sun {
+			t.Errorf("CalcAll() = %v, want result of Sun only", got)
+		}
+	})
+}
+
+type testSeriesIface struct{ Interface }
+
+func (testSeriesIface) Calc(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+	if et < 0 {
+		return nil, -1, Error{Code: -1, Message: "error"}
+	}
+
+	return []float64{et, 0, 1, 0, 0, 0}, 0, nil
+}
+
+func TestCalcSeries(t *testing.T) {
+	swe := testSeriesIface{}
+
+	t.Run("NoError", func(t *testing.T) {
+		ets := []float64{1, 2, 3}
+		got, errs := CalcSeries(swe, Mars, nil, ets)
+		if errs != nil {
+			t.Fatalf("CalcSeries() errs = %v, want: nil", errs)
+		}
+
+		for i, et := range ets {
+			if got[i].Planet != Mars || got[i].XX[0] != et {
+				t.Errorf("result %d = %v, want Mars at %f", i, got[i], et)
+			}
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		ets := []float64{1, -1, 3}
+		got, errs := CalcSeries(swe, Mars, nil, ets)
+		if len(errs) != len(ets) {
+			t.Fatalf("len(errs) = %d, want: %d", len(errs), len(ets))
+		}
+
+		for i, err := range errs {
+			if wantErr := i == 1; (err != nil) != wantErr {
+				t.Errorf("errs[%d] = %v, want error: %t", i, err, wantErr)
+			}
+		}
+
+		if got[1].XX != nil || got[2].XX[0] != 3 {
+			t.Errorf("CalcSeries() = %v, want calculation to continue after error", got)
+		}
+	})
+}
+
+type testDeltaTIface struct {
+	Interface
+	calls int
+	tacc  float64
+}
+
+func (ti *testDeltaTIface) DeltaTEx(jd float64, eph Ephemeris) (float64, error) {
+	ti.calls++
+	return jd * ti.tacc, nil
+}
+
+func (ti *testDeltaTIface) SetTidAcc(tacc float64) { ti.tacc = tacc }
+
+type testDeltaTLocker struct {
+	testDeltaTIface
+	locked bool
+}
+
+type testDeltaTLocked struct{ *testDeltaTLocker }
+
+func (l testDeltaTLocked) ExclusiveUnlock() { l.locked = false }
+
+func (ti *testDeltaTLocker) ExclusiveLock() LockedInterface {
+	ti.locked = true
+	return testDeltaTLocked{ti}
+}
+
+func TestCachedDeltaT_locked(t *testing.T) {
+	inner := &testDeltaTLocker{testDeltaTIface: testDeltaTIface{tacc: 1}}
+	swe := CachedDeltaT(inner)
+
+	Locked(swe, func(swe Interface) {
+		if !inner.locked {
+			t.Error("underlying handle not locked")
+		}
+
+		swe.DeltaTEx(2451545.0, JPL)
+	})
+
+	if inner.locked {
+		t.Error("underlying handle not unlocked")
+	}
+
+	swe.DeltaTEx(2451545.0, JPL)
+
+	if inner.calls != 1 {
+		t.Errorf("calls = %d, want: 1", inner.calls)
+	}
+
+	Locked(CachedDeltaT(new(testDeltaTIface)), func(swe Interface) {
+		if _, ok := swe.(nopLocked); ok {
+			t.Error("locked handle bypasses the cache")
+		}
+	})
+}
+
+func TestCachedDeltaT(t *testing.T) {
+	inner := &testDeltaTIface{tacc: 1}
+	swe := CachedDeltaT(inner)
+
+	for i := 0; i < 3; i++ {
+		if dt, _ := swe.DeltaTEx(2451545.0, JPL); dt != 2451545.0 {
+			t.Errorf("DeltaTEx() = %f, want: 2451545.0", dt)
+		}
+	}
+
+	swe.DeltaTEx(2451545.0, Moshier)
+	swe.DeltaTEx(2451546.0, JPL)
+
+	if inner.calls != 3 {
+		t.Errorf("calls = %d, want: 3", inner.calls)
+	}
+
+	swe.SetTidAcc(2)
+
+	if dt, _ := swe.DeltaTEx(2451545.0, JPL); dt != 2*2451545.0 {
+		t.Errorf("DeltaTEx() = %f after SetTidAcc, want: %f", dt, 2*2451545.0)
+	}
+
+	for i := 0; i < deltaTCacheSize+1; i++ {
+		swe.DeltaTEx(float64(i), JPL)
+	}
+
+	if n := swe.lru.Len(); n != deltaTCacheSize {
+		t.Errorf("cache size = %d, want: %d", n, deltaTCacheSize)
+	}
+
+	for _, jd := range []float64{1e11, -1e11, 2e11} {
+		if dt, _ := swe.DeltaTEx(jd, JPL); dt != 2*jd {
+			t.Errorf("DeltaTEx(%g) = %g, want: %g", jd, dt, 2*jd)
+		}
+	}
+}
+
+func TestDeltaTEx_warning(t *testing.T) {
+	warn := Error{Code: 0, Message: "file not found, using Moshier eph.", Warning: true}
+	calls := 0
+	swe := &Fake{
+		DeltaTExFunc: func(jd float64, eph Ephemeris) (float64, error) {
+			calls++
+			if jd == 3 {
+				return 0, Error{Code: -1, Message: "failure"}
+			}
+
+			return 0.001, warn
+		},
+	}
+
+	// the warning is cached along with the value
+	c := CachedDeltaT(swe)
+	for i := 0; i < 2; i++ {
+		if dt, err := c.DeltaTEx(1, Swiss); dt != 0.001 || err != warn {
+			t.Errorf("DeltaTEx() = %g, %v, want: 0.001, %v", dt, err, warn)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("calls = %d, want: 1", calls)
+	}
+
+	dts, err := DeltaTExSeries(swe, []float64{1, 2, 3, 4}, Swiss)
+	if len(dts) != 2 || err == nil || IsWarning(err) {
+		t.Errorf("DeltaTExSeries() = %v, %v, want 2 values and the error", dts, err)
+	}
+
+	dts, err = DeltaTExSeries(swe, []float64{1, 2}, Swiss)
+	if len(dts) != 2 || err != warn {
+		t.Errorf("DeltaTExSeries() = %v, %v, want 2 values and %v", dts, err, warn)
+	}
+
+	if et, err := JulianDayUT(1).ET(swe, Swiss); et != 1.001 || err != warn {
+		t.Errorf("ET() = %g, %v, want: 1.001, %v", et, err, warn)
+	}
+}
+
+type testDeltaTInvalidateIface struct {
+	Interface
+	c     *DeltaTCache
+	calls int
+}
+
+func (ti *testDeltaTInvalidateIface) DeltaTEx(jd float64, eph Ephemeris) (float64, error) {
+	ti.calls++
+	ti.c.Invalidate() // as if state changed concurrently
+	return 1, nil
+}
+
+func TestCachedDeltaT_invalidate(t *testing.T) {
+	inner := new(testDeltaTInvalidateIface)
+	swe := CachedDeltaT(inner)
+	inner.c = swe
+
+	swe.DeltaTEx(2451545.0, JPL)
+	swe.DeltaTEx(2451545.0, JPL)
+
+	if inner.calls != 2 {
+		t.Errorf("calls = %d, want: 2", inner.calls)
+	}
+}
+
+func TestGeoLoc_Array(t *testing.T) {
+	geo := GeoLoc{Long: 13.4, Lat: 52.5, Alt: 34}
+
+	if got, want := geo.Array(), [3]float64{13.4, 52.5, 34}; got != want {
+		t.Errorf("Array() = %v, want: %v", got, want)
+	}
+}
+
+func TestGeoLoc_Validate(t *testing.T) {
+	cases := []struct {
+		in  GeoLoc
+		err error
+	}{
+		{GeoLoc{Long: 13.4, Lat: 52.5, Alt: 34}, nil},
+		{GeoLoc{Long: -180, Lat: -90, Alt: MinAltitude}, nil},
+		{GeoLoc{Long: 180, Lat: 90, Alt: MaxAltitude}, nil},
+		{GeoLoc{Long: 13.4, Lat: 91}, Error{Code: -1, Message: "invalid geographic location: latitude 91"}},
+		{GeoLoc{Long: 200, Lat: 52.5}, Error{Code: -1, Message: "invalid geographic location: longitude 200"}},
+		{GeoLoc{Long: 13.4, Lat: 52.5, Alt: 100000}, Error{Code: -1, Message: "invalid geographic location: altitude 100000"}},
+		{GeoLoc{Long: 13.4, Lat: math.NaN()}, Error{Code: -1, Message: "invalid geographic location: latitude NaN"}},
+	}
+
+	for _, c := range cases {
+		if err := c.in.Validate(); err != c.err {
+			t.Errorf("%v.Validate() = %v, want: %v", c.in, err, c.err)
+		}
+	}
+}
+
+func TestCalcResult_JSON(t *testing.T) {
+	cases := []struct {
+		in   CalcResult
+		json string
+	}{
+		{
+			CalcResult{Mars, []float64{1, 2, 3, 4, 5, 6}, 258, nil},
+			`{"planet":4,"position":{"longitude":1,"latitude":2,"distance":3,` +
+				`"speedLong":4,"speedLat":5,"speedDist":6},"flags":258}`,
+		},
+		{
+			CalcResult{Chiron, []float64{1, 2, 3, 4, 5, 6}, 4, Error{Code: 4, Message: "moshier", Warning: true}},
+			`{"planet":15,"position":{"longitude":1,"latitude":2,"distance":3,` +
+				`"speedLong":4,"speedLat":5,"speedDist":6},"flags":4,"warning":"moshier"}`,
+		},
+		{CalcResult{Planet: Sun}, `{"planet":0,"flags":0}`},
+	}
+
+	for _, c := range cases {
+		data, err := json.Marshal(c.in)
+		if err != nil {
+			t.Fatalf("Marshal(%v) err = %q", c.in, err)
+		}
+
+		if string(data) != c.json {
+			t.Errorf("Marshal(%v) = %s, want: %s", c.in, data, c.json)
+		}
+
+		var got CalcResult
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s) err = %q", data, err)
+		}
+
+		if !reflect.DeepEqual(got, c.in) {
+			t.Errorf("Unmarshal(%s) = %v, want: %v", data, got, c.in)
+		}
+	}
+}
+
+func TestPhenoData_JSON(t *testing.T) {
+	data, err := json.Marshal(PhenoData{1, 2, 3, 4, 5})
+	if err != nil {
+		t.Fatalf("Marshal err = %q", err)
+	}
+
+	want := `{"phaseAngle":1,"phase":2,"elongation":3,"apparentDiameter":4,"apparentMagnitude":5}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want: %s", data, want)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		in   string
+		want SemVer
+		str  string
+	}{
+		{"2.06", SemVer{2, 6, 0, ""}, "2.06"},
+		{"2.10", SemVer{2, 10, 0, ""}, "2.10"},
+		{"2.10.03", SemVer{2, 10, 3, ""}, "2.10.03"},
+		{"2.10.03b", SemVer{2, 10, 3, "b"}, "2.10.03b"},
+		{"2.07-beta", SemVer{2, 7, 0, "beta"}, "2.07beta"},
+	}
+
+	for _, c := range cases {
+		got, err := ParseVersion(c.in)
+		if err != nil {
+			t.Errorf("ParseVersion(%q) err = %q", c.in, err)
+			continue
+		}
+
+		if got != c.want {
+			t.Errorf("ParseVersion(%q) = %v, want: %v", c.in, got, c.want)
+		}
+
+		if s := got.String(); s != c.str {
+			t.Errorf("String() = %q, want: %q", s, c.str)
+		}
+	}
+
+	for _, in := range []string{"", "2", "2.x", "2.06.01.01", "v2.06"} {
+		if _, err := ParseVersion(in); err == nil {
+			t.Errorf("ParseVersion(%q) err = nil, want error", in)
+		}
+	}
+}
+
+func TestSemVer_Compare(t *testing.T) {
+	cases := []struct {
+		v, w string
+		want int
+	}{
+		{"2.06", "2.06.00", 0},
+		{"2.06", "2.07", -1},
+		{"2.10", "2.09.99", 1},
+		{"2.10.03", "2.10.02", 1},
+		{"2.10.03b", "2.10.03", -1},
+		{"2.10.03a", "2.10.03b", -1},
+		{"3.00", "2.10.03", 1},
+	}
+
+	for _, c := range cases {
+		v, _ := ParseVersion(c.v)
+		w, _ := ParseVersion(c.w)
+		if got := v.Compare(w); got != c.want {
+			t.Errorf("%s.Compare(%s) = %d, want: %d", c.v, c.w, got, c.want)
+		}
+	}
+}
+
+func TestNull(t *testing.T) {
+	var swe Interface = Null{}
+
+	xx, cfl, err := swe.Calc(2451545.0, Sun, nil)
+	if xx != nil || cfl != 0 || err != nil {
+		t.Errorf("Calc() = %v, %d, %v, want: nil, 0, nil", xx, cfl, err)
+	}
+}
+
+func TestNull_helpers(t *testing.T) {
+	var swe Interface = Null{}
+
+	if _, _, err := NextStation(swe, 2451545.0, Mars, nil); err != ErrShortResult {
+		t.Errorf("NextStation() err = %v, want: %v", err, ErrShortResult)
+	}
+
+	if _, err := NextAspect(swe, 2451545.0, Sun, Mars, 90, nil); err != ErrShortResult {
+		t.Errorf("NextAspect() err = %v, want: %v", err, ErrShortResult)
+	}
+
+	if _, _, err := MoonPhase(swe, 2451545.0, nil); err != ErrShortResult {
+		t.Errorf("MoonPhase() err = %v, want: %v", err, ErrShortResult)
+	}
+
+	if _, err := moonElongation(swe, 2451545.0, nil); err != ErrShortResult {
+		t.Errorf("moonElongation() err = %v, want: %v", err, ErrShortResult)
+	}
+
+	if _, err := NextLunarPhase(swe, 2451545.0, FullMoon, nil); err != ErrShortResult {
+		t.Errorf("NextLunarPhase() err = %v, want: %v", err, ErrShortResult)
+	}
+
+	if _, _, err := VoidOfCourseMoon(swe, 2451545.0, nil); err != ErrShortResult {
+		t.Errorf("VoidOfCourseMoon() err = %v, want: %v", err, ErrShortResult)
+	}
+
+	if _, _, err := SolarPhaseCondition(swe, 2451545.0, Venus, nil, nil); err != ErrShortResult {
+		t.Errorf("SolarPhaseCondition() err = %v, want: %v", err, ErrShortResult)
+	}
+
+	if _, err := ComputeChart(swe, 2451545.0, &GeoLoc{}, Placidus, []Planet{Sun}, nil); err != ErrShortResult {
+		t.Errorf("ComputeChart() err = %v, want: %v", err, ErrShortResult)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder(nil)
+	fl := NewCalcFlags().Speed()
+
+	r.Calc(2451545.0, Moon, fl)
+	r.HouseName(Placidus)
+
+	want := []Call{
+		{"Calc", []interface{}{2451545.0, Moon, fl}},
+		{"HouseName", []interface{}{Placidus}},
+	}
+
+	if got := r.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Calls() = %v, want: %v", got, want)
+	}
+
+	r.Reset()
+
+	if got := r.Calls(); len(got) != 0 {
+		t.Errorf("Calls() after Reset = %v, want: []", got)
+	}
+}
+
+func TestRecorder_locked(t *testing.T) {
+	inner := &testDeltaTLocker{testDeltaTIface: testDeltaTIface{tacc: 1}}
+	r := NewRecorder(inner)
+
+	Locked(r, func(swe Interface) {
+		if !inner.locked {
+			t.Error("underlying handle not locked")
+		}
+
+		swe.DeltaTEx(2451545.0, JPL)
+	})
+
+	if inner.locked {
+		t.Error("underlying handle not unlocked")
+	}
+
+	want := []Call{{"DeltaTEx", []interface{}{2451545.0, JPL}}}
+	if got := r.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Calls() = %v, want: %v", got, want)
+	}
+}
+
+func TestFake(t *testing.T) {
+	fake := new(Fake)
+	fake.SetCalc(2451545.0, Sun, []float64{280.4, 0, 1, 1, 0, 0}, 2, nil)
+	fake.CalcFunc = func(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+		return []float64{float64(pl), 0, 0, 0, 0, 0}, 4, nil
+	}
+
+	fake.HouseNameFunc = func(hsys HSys) (string, error) { return "fake", nil }
+
+	var swe Interface = fake
+
+	if xx, cfl, _ := swe.Calc(2451545.0, Sun, nil); xx[0] != 280.4 || cfl != 2 {
+		t.Errorf("Calc(Sun) = %v, %d, want registered response", xx, cfl)
+	}
+
+	if xx, cfl, _ := swe.Calc(2451545.0, Mars, nil); xx[0] != float64(Mars) || cfl != 4 {
+		t.Errorf("Calc(Mars) = %v, %d, want CalcFunc response", xx, cfl)
+	}
+
+	if xx, _, err := swe.CalcUT(2451545.0, Sun, nil); len(xx) != 6 || err != nil {
+		t.Errorf("CalcUT() = %v, %v, want zero position", xx, err)
+	}
+
+	if name, _ := swe.HouseName(Koch); name != "fake" {
+		t.Errorf("HouseName() = %q, want: fake", name)
+	}
+
+	if name, err := swe.PlanetName(Sun); name != "" || err != nil {
+		t.Errorf("PlanetName() = %q, %v, want: \"\", nil", name, err)
+	}
+}
+
+func TestFake_concurrent(t *testing.T) {
+	fake := new(Fake)
+
+	go fake.SetCalc(2451545.0, Sun, []float64{1, 0, 0, 0, 0, 0}, 0, nil)
+	go fake.SetCalcUT(2451545.0, Sun, []float64{1, 0, 0, 0, 0, 0}, 0, nil)
+
+	for done := 0; done != 2; {
+		done = 0
+		if xx, _, _ := fake.Calc(2451545.0, Sun, nil); xx[0] == 1 {
+			done++
+		}
+
+		if xx, _, _ := fake.CalcUT(2451545.0, Sun, nil); xx[0] == 1 {
+			done++
+		}
+	}
+}
+
+func TestCalcAsteroid(t *testing.T) {
+	swe := &Fake{
+		CalcFunc: func(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+			return nil, -1, Error{Code: -1, Message: "SwissEph file 'se00099s.se1' not found in PATH '.'"}
+		},
+		PlanetNameFunc: func(pl Planet) (string, error) {
+			return strconv.Itoa(int(pl)), nil
+		},
+	}
+
+	swe.SetCalc(0, AstOffset+433, []float64{1, 2, 3, 4, 5, 6}, 2, nil)
+
+	xx, cfl, err := CalcAsteroid(swe, 0, 433, nil)
+	if err != nil || cfl != 2 || len(xx) != 6 {
+		t.Errorf("CalcAsteroid(433) = (%v, %d, %v), want: (xx, 2, nil)", xx, cfl, err)
+	}
+
+	_, _, err = CalcAsteroid(swe, 0, 99, nil)
+	if e, ok := err.(*AsteroidFileError); !ok || e.Number != 99 {
+		t.Errorf("CalcAsteroid(99) err = %#v, want: *AsteroidFileError", err)
+	}
+
+	if _, _, err := CalcAsteroid(swe, 0, 0, nil); err != ErrAsteroidNumber {
+		t.Errorf("CalcAsteroid(0) err = %v, want: %v", err, ErrAsteroidNumber)
+	}
+
+	if name, err := AsteroidName(swe, 433); name != "10433" || err != nil {
+		t.Errorf("AsteroidName(433) = (%q, %v), want: (\"10433\", nil)", name, err)
+	}
+
+	if _, err := AsteroidName(swe, -1); err != ErrAsteroidNumber {
+		t.Errorf("AsteroidName(-1) err = %v, want: %v", err, ErrAsteroidNumber)
+	}
+}
+
+// testHousesFake returns a Fake of which HousesEx fails for Placidus above
+// the polar circle.
+func testHousesFake() *Fake {
+	return &Fake{
+		HousesExFunc: func(ut float64, fl *HousesExFlags, geo *GeoLoc, hsys HSys) ([]float64, []float64, error) {
+			ascmc := []float64{1, 2, 3, 4, 5, 6, 7, 8, 0, 0}
+			if geo.Lat > 66 && hsys == 'P' {
+				return make([]float64, 13), ascmc, Error{Code: -1}
+			}
+
+			return make([]float64, 13), ascmc, nil
+		},
+	}
+}
+
+func TestChartAngles(t *testing.T) {
+	swe := testHousesFake()
+
+	asc, mc, armc, vertex, eqasc, err := ChartAngles(swe, 0, &GeoLoc{Lat: 52}, 'P')
+	if asc != 1 || mc != 2 || armc != 3 || vertex != 4 || eqasc != 5 || err != nil {
+		t.Errorf("ChartAngles() = (%g, %g, %g, %g, %g, %v), want: (1, 2, 3, 4, 5, nil)",
+			asc, mc, armc, vertex, eqasc, err)
+	}
+
+	if _, _, _, _, _, err := ChartAngles(swe, 0, &GeoLoc{Lat: 70}, 'P'); err == nil {
+		t.Error("ChartAngles(lat 70) err = nil, want error")
+	}
+}
+
+// testEclNutFake returns a Fake of which Calc returns the obliquity and the
+// nutation only for EclNut.
+func testEclNutFake() *Fake {
+	swe := &Fake{
+		CalcFunc: func(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+			return nil, -1, Error{Code: -1, Message: "illegal planet number"}
+		},
+	}
+
+	swe.SetCalc(0, EclNut, []float64{23.4, 23.5, -0.004, 0.002, 0, 0}, 0, nil)
+	return swe
+}
+
+func TestObliquity(t *testing.T) {
+	trueEps, meanEps, nutLong, nutObl, err := Obliquity(testEclNutFake(), 0, nil)
+	if trueEps != 23.4 || meanEps != 23.5 || nutLong != -0.004 || nutObl != 0.002 || err != nil {
+		t.Errorf("Obliquity() = (%g, %g, %g, %g, %v), want: (23.4, 23.5, -0.004, 0.002, nil)",
+			trueEps, meanEps, nutLong, nutObl, err)
+	}
+}
+
+func TestNutation(t *testing.T) {
+	dpsi, deps, err := Nutation(testEclNutFake(), 0, nil)
+	if dpsi != -0.004 || deps != 0.002 || err != nil {
+		t.Errorf("Nutation() = (%g, %g, %v), want: (-0.004, 0.002, nil)", dpsi, deps, err)
+	}
+}
+
+// testFlagsFake returns a Fake of which Calc returns the flags it is called
+// with as cfl and as first coordinate.
+func testFlagsFake() *Fake {
+	return &Fake{
+		CalcFunc: func(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+			return []float64{float64(fl.Flags), 2, 3, 4, 5, 6}, int(fl.Flags), nil
+		},
+	}
+}
+
+func TestCalcHeliocentric(t *testing.T) {
+	fl := &CalcFlags{Flags: FlagEphMoshier}
+
+	_, cfl, err := CalcHeliocentric(testFlagsFake(), 0, Mars, fl)
+	if err != nil || cfl != FlagEphMoshier|FlagHelio {
+		t.Errorf("CalcHeliocentric(Mars) = (%d, %v), want: (%d, nil)", cfl, err, FlagEphMoshier|FlagHelio)
+	}
+
+	if fl.Flags != FlagEphMoshier {
+		t.Errorf("CalcHeliocentric() modified fl: %v", fl)
+	}
+
+	for _, pl := range []Planet{Sun, MeanNode, OscuApogee, InterPerigee} {
+		if _, _, err := CalcHeliocentric(testFlagsFake(), 0, pl, nil); err != ErrNoHeliocentric {
+			t.Errorf("CalcHeliocentric(%d) err = %v, want: %v", pl, err, ErrNoHeliocentric)
+		}
+	}
+}
+
+func TestCalcBarycentric(t *testing.T) {
+	_, cfl, err := CalcBarycentric(testFlagsFake(), 0, Sun, nil)
+	if err != nil || cfl != FlagBary {
+		t.Errorf("CalcBarycentric(Sun) = (%d, %v), want: (%d, nil)", cfl, err, FlagBary)
+	}
+
+	swe := &Fake{
+		CalcFunc: func(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+			return make([]float64, 6), FlagBary | FlagEphMoshier, Error{Code: 0, Message: "fallback to Moshier", Warning: true}
+		},
+	}
+
+	xx, cfl, err := CalcBarycentric(swe, 0, Sun, nil)
+	if xx != nil || cfl != -1 || err != ErrMoshierBarycentric {
+		t.Errorf("CalcBarycentric(Sun) = (%v, %d, %v), want: (nil, -1, %v)", xx, cfl, err, ErrMoshierBarycentric)
+	}
+}
+
+func TestCalcEquatorial(t *testing.T) {
+	ra, dec, dist, raSpeed, decSpeed, distSpeed, err := CalcEquatorial(testFlagsFake(), 0, Mars, nil)
+	if ra != FlagEquatorial || dec != 2 || dist != 3 || raSpeed != 4 || decSpeed != 5 || distSpeed != 6 || err != nil {
+		t.Errorf("CalcEquatorial() = (%g, %g, %g, %g, %g, %g, %v), want: (%d, 2, 3, 4, 5, 6, nil)",
+			ra, dec, dist, raSpeed, decSpeed, distSpeed, err, FlagEquatorial)
+	}
+}
+
+func TestCalcXYZ(t *testing.T) {
+	x, y, z, vx, vy, vz, err := CalcXYZ(testFlagsFake(), 0, Mars, nil)
+	if x != FlagXYZ || y != 2 || z != 3 || vx != 4 || vy != 5 || vz != 6 || err != nil {
+		t.Errorf("CalcXYZ() = (%g, %g, %g, %g, %g, %g, %v), want: (%d, 2, 3, 4, 5, 6, nil)",
+			x, y, z, vx, vy, vz, err, FlagXYZ)
+	}
+}
+
+func TestCalcJ2000(t *testing.T) {
+	_, cfl, err := CalcJ2000(testFlagsFake(), 0, Mars, &CalcFlags{Flags: FlagSpeed})
+	if err != nil || cfl != FlagSpeed|FlagJ2000 {
+		t.Errorf("CalcJ2000() = (%d, %v), want: (%d, nil)", cfl, err, FlagSpeed|FlagJ2000)
+	}
+}
+
+func TestSpeed(t *testing.T) {
+	var flags int
+	swe := &Fake{
+		CalcFunc: func(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+			flags = int(fl.Flags)
+			return []float64{1, 2, 3, 4, 5, 6}, int(fl.Flags), nil
+		},
+	}
+
+	lngSpeed, latSpeed, distSpeed, err := Speed(swe, 0, Mars, &CalcFlags{Flags: FlagEphMoshier})
+	if lngSpeed != 4 || latSpeed != 5 || distSpeed != 6 || err != nil {
+		t.Errorf("Speed() = (%g, %g, %g, %v), want: (4, 5, 6, nil)", lngSpeed, latSpeed, distSpeed, err)
+	}
+
+	if want := FlagEphMoshier | FlagSpeed; flags != want {
+		t.Errorf("Speed() flags = %d, want: %d", flags, want)
+	}
+}
+
+func TestCalcGeometric(t *testing.T) {
+	const want = FlagEphMoshier | FlagTruePos | FlagNoAbber | FlagNoGDefl
+
+	_, cfl, err := CalcGeometric(testFlagsFake(), 0, Mars, &CalcFlags{Flags: FlagEphMoshier})
+	if err != nil || cfl != want {
+		t.Errorf("CalcGeometric() = (%d, %v), want: (%d, nil)", cfl, err, want)
+	}
+}
+
+func TestCalcAstrometric(t *testing.T) {
+	const want = FlagJ2000 | FlagNoAbber | FlagNoGDefl | FlagEquatorial
+
+	ra, dec, dist, err := CalcAstrometric(testFlagsFake(), 0, Mars, nil)
+	if ra != want || dec != 2 || dist != 3 || err != nil {
+		t.Errorf("CalcAstrometric() = (%g, %g, %g, %v), want: (%d, 2, 3, nil)", ra, dec, dist, err, want)
+	}
+}
+
+func TestAngleDiff(t *testing.T) {
+	cases := []struct{ in, want float64 }{
+		{0, 0}, {179, 179}, {180, -180}, {-181, 179}, {350, -10}, {-710, 10},
+	}
+
+	for _, c := range cases {
+		if got := angleDiff(c.in); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("angleDiff(%g) = %g, want: %g", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSolarPhaseCondition(t *testing.T) {
+	const sun = 358.
+
+	cases := []struct {
+		lng       float64
+		orbs      *SolarPhaseOrbs
+		elong     float64
+		condition string
+	}{
+		{358.1, nil, .1, "cazimi"},
+		{3, nil, 5, "combust"},
+		{350, nil, 8, "combust"},
+		{10, nil, 12, "under the beams"},
+		{13, nil, 15, "under the beams"},
+		{100, nil, 102, "free"},
+		{180, nil, 178, "free"},
+		{10, &SolarPhaseOrbs{Cazimi: 1, Combust: 12, UnderBeams: 17}, 12, "combust"},
+	}
+
+	for _, c := range cases {
+		swe := &Fake{
+			CalcUTFunc: func(ut float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+				if pl == Sun {
+					return []float64{sun, 0, 1, 0, 0, 0}, 0, nil
+				}
+
+				return []float64{c.lng, 0, 1, 0, 0, 0}, 0, nil
+			},
+		}
+
+		elong, condition, err := SolarPhaseCondition(swe, 0, Venus, nil, c.orbs)
+		if math.Abs(elong-c.elong) > 1e-9 || condition != c.condition || err != nil {
+			t.Errorf("SolarPhaseCondition(%g) = (%g, %q, %v), want: (%g, %q, nil)",
+				c.lng, elong, condition, err, c.elong, c.condition)
+		}
+	}
+
+	if _, _, err := SolarPhaseCondition(&Fake{}, 0, Sun, nil, nil); err != ErrSolarPhaseSun {
+		t.Errorf("SolarPhaseCondition(Sun) err = %v, want: %v", err, ErrSolarPhaseSun)
+	}
+}
+
+func TestAntiscion(t *testing.T) {
+	cases := []struct{ in, anti, contra float64 }{
+		{0, 180, 0},
+		{10, 170, 350},
+		{90, 90, 270},
+		{135, 45, 225},
+		{270, 270, 90},
+		{300, 240, 60},
+	}
+
+	for _, c := range cases {
+		if got := Antiscion(c.in); math.Abs(got-c.anti) > 1e-9 {
+			t.Errorf("Antiscion(%g) = %g, want: %g", c.in, got, c.anti)
+		}
+
+		if got := ContraAntiscion(c.in); math.Abs(got-c.contra) > 1e-9 {
+			t.Errorf("ContraAntiscion(%g) = %g, want: %g", c.in, got, c.contra)
+		}
+	}
+}
+
+func TestJulianDay(t *testing.T) {
+	jd := JulianDay(2451545.0)
+
+	if got, want := jd.AddDays(1.5), JulianDay(2451546.5); got != want {
+		t.Errorf("AddDays(1.5) = %f, want: %f", got, want)
+	}
+
+	if got, want := jd.Time(), time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Time() = %s, want: %s", got, want)
+	}
+
+	if got, want := jd.String(), "2000-01-01 12:00:00 ET"; got != want {
+		t.Errorf("String() = %q, want: %q", got, want)
+	}
+
+	ut := JulianDayUT(2440587.5).AddDays(-0.25)
+	if got, want := ut.String(), "1969-12-31 18:00:00 UT"; got != want {
+		t.Errorf("String() = %q, want: %q", got, want)
+	}
+
+	if got, want := JulianDayUT(2299160.5).Time(), time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Time() = %s, want: %s", got, want)
+	}
+}
+
+func TestJulianDay_Calc(t *testing.T) {
+	var called string
+	swe := &Fake{
+		CalcFunc: func(et float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+			called = "Calc"
+			return []float64{et, 0, 0, 0, 0, 0}, 0, nil
+		},
+		CalcUTFunc: func(ut float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+			called = "CalcUT"
+			return []float64{ut, 0, 0, 0, 0, 0}, 0, nil
+		},
+	}
+
+	if xx, _, _ := JulianDay(2451545).Calc(swe, Sun, nil); called != "Calc" || xx[0] != 2451545 {
+		t.Errorf("JulianDay.Calc() called %s(%g), want: Calc(2451545)", called, xx[0])
+	}
+
+	if xx, _, _ := JulianDayUT(2451545).Calc(swe, Sun, nil); called != "CalcUT" || xx[0] != 2451545 {
+		t.Errorf("JulianDayUT.Calc() called %s(%g), want: CalcUT(2451545)", called, xx[0])
+	}
+}
+
+func TestJulianDay_UT(t *testing.T) {
+	swe := &Fake{
+		DeltaTExFunc: func(jd float64, eph Ephemeris) (float64, error) {
+			return .0008 + (jd-2451545)*1e-6, nil
+		},
+	}
+
+	const ut = JulianDayUT(2460000.5)
+
+	et, err := ut.ET(swe, Moshier)
+	if want := JulianDay(2460000.5 + .0008 + 8455.5e-6); err != nil || math.Abs(float64(et-want)) > 1e-12 {
+		t.Errorf("ET() = (%f, %v), want: (%f, nil)", et, err, want)
+	}
+
+	got, err := et.UT(swe, Moshier)
+	if err != nil || math.Abs(float64(got-ut)) > 1e-9 {
+		t.Errorf("UT() = (%f, %v), want: (%f, nil)", got, err, ut)
+	}
+
+	swe.DeltaTExFunc = func(jd float64, eph Ephemeris) (float64, error) {
+		return 0, ErrInvalidDate
+	}
+
+	if _, err := ut.ET(swe, Moshier); err != ErrInvalidDate {
+		t.Errorf("ET() err = %v, want: %v", err, ErrInvalidDate)
+	}
+}
+
+func TestNextLunarPhase_noConvergence(t *testing.T) {
+	swe := &Fake{
+		CalcUTFunc: func(ut float64, pl Planet, fl *CalcFlags) ([]float64, int, error) {
+			return make([]float64, 6), 0, nil
+		},
+		MoonCrossUTFunc: func(x2cross, ut float64, fl *CalcFlags) (float64, error) {
+			return ut + 1, nil // never converges
+		},
+	}
+
+	if _, err := NextLunarPhase(swe, 2451545, FullMoon, nil); err != ErrNoConvergence {
+		t.Errorf("NextLunarPhase() err = %v, want: %v", err, ErrNoConvergence)
+	}
+}
+
+func TestJulianDayFromEpoch(t *testing.T) {
+	cases := []struct {
+		epoch string
+		jd    float64
+	}{
+		{"J2000.0", 2451545.0},
+		{"J1991.25", 2448349.0625},
+		{"J2100.0", 2488070.0},
+		{"B1900.0", 2415020.31352},
+		{"B1950.0", 2433282.4235},
+	}
+
+	for _, c := range cases {
+		jd, err := JulianDayFromEpoch(c.epoch)
+		if err != nil || math.Abs(jd-c.jd) > 1e-4 {
+			t.Errorf("JulianDayFromEpoch(%q) = (%f, %v), want: %f", c.epoch, jd, err, c.jd)
+		}
+
+		besselian := c.epoch[0] == 'B'
+		if got := EpochFromJulianDay(jd, besselian); got != c.epoch {
+			t.Errorf("EpochFromJulianDay(%f, %t) = %q, want: %q", jd, besselian, got, c.epoch)
+		}
+	}
+
+	for _, s := range []string{"", "J", "2000.0", "X2000.0", "J20a0", "J2e3", "JInf", "JNaN", "B-inf", "J+inf"} {
+		if _, err := JulianDayFromEpoch(s); err == nil {
+			t.Errorf("JulianDayFromEpoch(%q) err = nil, want error", s)
+		}
+	}
+}
+
+func TestHousesSeries(t *testing.T) {
+	swe := testHousesFake()
+
+	res, errs := HousesSeries(swe, []float64{1, 2}, &GeoLoc{Lat: 52}, Placidus)
+	if len(res) != 2 || res[1].Ascendant != 1 || errs != nil {
+		t.Errorf("HousesSeries(lat 52) = (%v, %v), want 2 results without errors", res, errs)
+	}
+
+	res, errs = HousesSeries(swe, []float64{1, 2}, &GeoLoc{Lat: 70}, Placidus)
+	if len(errs) != 2 || errs[0] == nil || res[0].MC != 2 {
+		t.Errorf("HousesSeries(lat 70) = (%v, %v), want results with errors", res, errs)
+	}
+}
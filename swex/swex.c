@@ -1,9 +1,18 @@
+/* strdup and fmemopen are POSIX, not C99. */
+#define _POSIX_C_SOURCE 200809L
+
 #include "swex.h"
 
 #include <swephexp.h>
 #include <sweph.h>
+#include <swephlib.h>
 #include "sweversion.h"
 
+#include <ctype.h>
+#include <math.h>
+#include <stdio.h>
+#include <string.h>
+
 bool swex_supports_tls() {
 #if defined(TLSOFF) && TLSOFF == 1
 	return false;
@@ -47,3 +56,797 @@ void swex_set_sid_mode(int32_t sidm, double t0, double ayan_t0) {
 
 	swe_set_sid_mode(sidm, t0, ayan_t0);
 }
+
+void swex_get_astro_models(char *samod) {
+  int i;
+
+  *samod = '\0';
+  for (i = 0; i < NSE_MODELS; i++) {
+    sprintf(samod + strlen(samod), i == 0 ? "%d" : ",%d", swed.astro_models[i]);
+  }
+}
+
+double swex_sidtime_model(double tjd_ut, int32_t model) {
+  int32 saved = swed.astro_models[SE_MODEL_SIDT];
+  double sidt;
+
+  swed.astro_models[SE_MODEL_SIDT] = model;
+  sidt = swe_sidtime(tjd_ut);
+  swed.astro_models[SE_MODEL_SIDT] = saved;
+  return sidt;
+}
+
+const char *swex_get_current_file_data(int ifno, double *tfstart, double *tfend, int *denum) {
+  struct file_data *fd;
+
+  if (ifno < SEI_FILE_PLANET || ifno > SEI_FILE_FIXSTAR) {
+    return NULL;
+  }
+
+  fd = &swed.fidat[ifno];
+  if (*fd->fnam == '\0') {
+    return NULL;
+  }
+
+  *tfstart = fd->tfstart;
+  *tfend = fd->tfend;
+  *denum = fd->sweph_denum;
+  return fd->fnam;
+}
+
+/* swex_ephe_file_ok reports whether file ifno was read by the last
+ * calculation at tjd and is the file fname. */
+static bool swex_ephe_file_ok(double tjd, int ifno, const char *fname) {
+  struct file_data *fd = &swed.fidat[ifno];
+  char *sp;
+
+  if (fd->fptr == NULL || tjd < fd->tfstart || tjd > fd->tfend) {
+    return false;
+  }
+
+  sp = strrchr(fd->fnam, (int) *DIR_GLUE);
+  return strcmp(sp == NULL ? fd->fnam : sp + 1, fname) == 0;
+}
+
+bool swex_find_ephe_file(double tjd, int ifno, char *fname) {
+  char serr[AS_MAXCH], plfname[AS_MAXCH];
+  double xx[6];
+  FILE *fp;
+  int i, ipl, ipli;
+
+  switch (ifno) {
+  case SEI_FILE_PLANET:
+    ipl = SE_MARS;
+    ipli = SEI_EMB;
+    break;
+  case SEI_FILE_MOON:
+    ipl = SE_MOON;
+    ipli = SEI_MOON;
+    break;
+  case SEI_FILE_MAIN_AST:
+    ipl = SE_CERES;
+    ipli = SEI_CHIRON;
+    break;
+  default:
+    *fname = '\0';
+    return false;
+  }
+
+  /* a trial calculation, the saved positions are discarded so that the
+   * files are read */
+  for (i = 0; i < SEI_NPLANETS; i++) {
+    swed.pldat[i].teval = 0;
+  }
+
+  swed.savedat[ipl].tsave = 0;
+  swi_gen_filename(tjd, ipli, fname);
+  swe_calc(tjd, ipl, SEFLG_SWIEPH, xx, serr);
+  if (swex_ephe_file_ok(tjd, ifno, fname)) {
+    return true;
+  }
+
+  /* the Moon and the asteroids are computed relative to the bodies of the
+   * planet file, without it only check that the file exists */
+  swi_gen_filename(tjd, SEI_EMB, plfname);
+  if (ifno == SEI_FILE_PLANET || swex_ephe_file_ok(tjd, SEI_FILE_PLANET, plfname)) {
+    return false;
+  }
+
+  fp = swi_fopen(-1, fname, swed.ephepath, NULL);
+  if (fp == NULL) {
+    return false;
+  }
+
+  fclose(fp);
+  return true;
+}
+
+/* Crossing functions, a local reimplementation modelled on the API of Swiss
+ * Ephemeris 2.07. Unlike 2.07 they return OK or ERR and the crossing time in
+ * jd_cross, swex_solcross and swex_helio_cross take a direction, and the
+ * search is a Newton iteration in swex_cross, not the code of 2.07. */
+
+#define SWEX_CROSS_PRECISION (1 / 3600000.0) /* one milliarc second */
+#define SWEX_CROSS_MAX_ITER 100
+
+static int32 swex_calc(AS_BOOL ut, double jd, int32 ipl, int32 iflag, double *xx, char *serr) {
+  if (ut) {
+    return swe_calc_ut(jd, ipl, iflag, xx, serr);
+  }
+
+  return swe_calc(jd, ipl, iflag, xx, serr);
+}
+
+/* swex_cross searches the crossing of longitude x2cross by planet ipl. If
+ * speed is 0 the speed of the planet at jd is used as mean speed. */
+static int32 swex_cross(AS_BOOL ut, int32 ipl, double x2cross, double jd, int32 iflag, double speed, int32 dir, double *jd_cross, char *serr) {
+  double x[6], dist;
+  int i;
+
+  iflag |= SEFLG_SPEED;
+  if (swex_calc(ut, jd, ipl, iflag, x, serr) == ERR) {
+    return ERR;
+  }
+
+  if (speed == 0) {
+    speed = x[3];
+  }
+
+  dist = swe_degnorm(x2cross - x[0]);
+  if (dir < 0 && dist > 0) {
+    dist -= 360;
+  }
+
+  jd += dist / speed;
+  for (i = 0; i < SWEX_CROSS_MAX_ITER; i++) {
+    if (swex_calc(ut, jd, ipl, iflag, x, serr) == ERR) {
+      return ERR;
+    }
+
+    dist = swe_difdeg2n(x2cross, x[0]);
+    jd += dist / x[3];
+    if (fabs(dist) < SWEX_CROSS_PRECISION) {
+      *jd_cross = jd;
+      return OK;
+    }
+  }
+
+  if (serr != NULL) {
+    strcpy(serr, "no convergence in crossing search");
+  }
+
+  return ERR;
+}
+
+int32_t swex_solcross(double x2cross, double jd_et, int32_t iflag, int32_t dir, double *jd_cross, char *serr) {
+  return swex_cross(FALSE, SE_SUN, x2cross, jd_et, iflag, 360.0 / 365.24, dir, jd_cross, serr);
+}
+
+int32_t swex_solcross_ut(double x2cross, double jd_ut, int32_t iflag, int32_t dir, double *jd_cross, char *serr) {
+  return swex_cross(TRUE, SE_SUN, x2cross, jd_ut, iflag, 360.0 / 365.24, dir, jd_cross, serr);
+}
+
+int32_t swex_mooncross(double x2cross, double jd_et, int32_t iflag, double *jd_cross, char *serr) {
+  return swex_cross(FALSE, SE_MOON, x2cross, jd_et, iflag, 360.0 / 27.32, 1, jd_cross, serr);
+}
+
+int32_t swex_mooncross_ut(double x2cross, double jd_ut, int32_t iflag, double *jd_cross, char *serr) {
+  return swex_cross(TRUE, SE_MOON, x2cross, jd_ut, iflag, 360.0 / 27.32, 1, jd_cross, serr);
+}
+
+static int32 swex_mooncross_node_any(AS_BOOL ut, double jd, int32 iflag, double *jd_cross, double *xlon, double *xlat, char *serr) {
+  double x[6], xlat0;
+  int i;
+
+  iflag |= SEFLG_SPEED;
+  if (swex_calc(ut, jd, SE_MOON, iflag, x, serr) == ERR) {
+    return ERR;
+  }
+
+  /* step in days until the latitude changes sign, if the search starts at a
+   * node use the direction of motion to skip it */
+  xlat0 = x[1];
+  if (fabs(xlat0) < SWEX_CROSS_PRECISION) {
+    xlat0 = x[4];
+  }
+
+  for (i = 0; i < 30; i++) {
+    jd += 1;
+    if (swex_calc(ut, jd, SE_MOON, iflag, x, serr) == ERR) {
+      return ERR;
+    }
+
+    if ((xlat0 < 0) != (x[1] < 0)) {
+      break;
+    }
+
+    xlat0 = x[1];
+  }
+
+  for (i = 0; i < SWEX_CROSS_MAX_ITER; i++) {
+    jd -= x[1] / x[4];
+    if (swex_calc(ut, jd, SE_MOON, iflag, x, serr) == ERR) {
+      return ERR;
+    }
+
+    if (fabs(x[1]) < SWEX_CROSS_PRECISION) {
+      *jd_cross = jd;
+      *xlon = x[0];
+      *xlat = x[1];
+      return OK;
+    }
+  }
+
+  if (serr != NULL) {
+    strcpy(serr, "no convergence in crossing search");
+  }
+
+  return ERR;
+}
+
+int32_t swex_mooncross_node(double jd_et, int32_t iflag, double *jd_cross, double *xlon, double *xlat, char *serr) {
+  return swex_mooncross_node_any(FALSE, jd_et, iflag, jd_cross, xlon, xlat, serr);
+}
+
+int32_t swex_mooncross_node_ut(double jd_ut, int32_t iflag, double *jd_cross, double *xlon, double *xlat, char *serr) {
+  return swex_mooncross_node_any(TRUE, jd_ut, iflag, jd_cross, xlon, xlat, serr);
+}
+
+static int32 swex_helio_cross_any(AS_BOOL ut, int32 ipl, double x2cross, double jd, int32 iflag, int32 dir, double *jd_cross, char *serr) {
+  char name[AS_MAXCH];
+
+  if (ipl == SE_SUN || ipl == SE_MOON
+    || (ipl >= SE_MEAN_NODE && ipl <= SE_OSCU_APOG)
+    || ipl == SE_INTP_APOG || ipl == SE_INTP_PERG
+  ) {
+    if (serr != NULL) {
+      swe_get_planet_name(ipl, name);
+      sprintf(serr, "heliocentric crossing not possible for object %d = %s", ipl, name);
+    }
+
+    return ERR;
+  }
+
+  return swex_cross(ut, ipl, x2cross, jd, iflag | SEFLG_HELCTR, 0, dir, jd_cross, serr);
+}
+
+int32_t swex_helio_cross(int32_t ipl, double x2cross, double jd_et, int32_t iflag, int32_t dir, double *jd_cross, char *serr) {
+  return swex_helio_cross_any(FALSE, ipl, x2cross, jd_et, iflag, dir, jd_cross, serr);
+}
+
+int32_t swex_helio_cross_ut(int32_t ipl, double x2cross, double jd_ut, int32_t iflag, int32_t dir, double *jd_cross, char *serr) {
+  return swex_helio_cross_any(TRUE, ipl, x2cross, jd_ut, iflag, dir, jd_cross, serr);
+}
+
+/* Indexed fixed star lookup, a local reimplementation modelled on the
+ * swe_fixstar2 API of Swiss Ephemeris 2.07. The star file is read once into
+ * an index sorted by traditional name and by nomenclature name. The star
+ * found is passed to swe_fixstar in a memory stream replacing the star file,
+ * so the search matches swe_fixstar and only the file scan is avoided. */
+
+struct swex_star {
+  int line;                     /* star number, comments are not counted */
+  char name[SE_MAX_STNAME + 1]; /* traditional name, lower case, no spaces */
+  char *nomclat;                /* data from the comma before the nomenclature */
+  char *data;                   /* line of the star file */
+};
+
+static struct swex_star *swex_stars;
+static struct swex_star **swex_stars_by_name;
+static struct swex_star **swex_stars_by_nomclat;
+static int swex_nstars;
+static FILE *swex_stars_fp;
+static char swex_stars_path[AS_MAXCH];
+
+static void swex_free_stars(void) {
+  int i;
+
+  for (i = 0; i < swex_nstars; i++) {
+    free(swex_stars[i].data);
+  }
+
+  free(swex_stars);
+  free(swex_stars_by_name);
+  free(swex_stars_by_nomclat);
+  swex_stars = NULL;
+  swex_stars_by_name = NULL;
+  swex_stars_by_nomclat = NULL;
+  swex_nstars = 0;
+  swex_stars_fp = NULL;
+}
+
+static int swex_cmp_star_name(const void *a, const void *b) {
+  return strcmp((*(struct swex_star **)a)->name, (*(struct swex_star **)b)->name);
+}
+
+static int swex_cmp_star_nomclat(const void *a, const void *b) {
+  return strcmp((*(struct swex_star **)a)->nomclat, (*(struct swex_star **)b)->nomclat);
+}
+
+/* swex_load_stars reads the star file into the index, unless the index is of
+ * the star file currently open. It returns ERR if there is no star file. */
+static int32 swex_load_stars(void) {
+  char s[AS_MAXCH], *sp, *dp;
+  struct swex_star *star;
+  int n = 0, i;
+
+  if (swed.fixfp != NULL && swed.fixfp == swex_stars_fp
+    && strcmp(swed.ephepath, swex_stars_path) == 0
+  ) {
+    return OK;
+  }
+
+  swex_free_stars();
+  if (swed.fixfp == NULL) {
+    swed.is_old_starfile = FALSE;
+    if ((swed.fixfp = swi_fopen(SEI_FILE_FIXSTAR, SE_STARFILE, swed.ephepath, NULL)) == NULL) {
+      swed.is_old_starfile = TRUE;
+      if ((swed.fixfp = swi_fopen(SEI_FILE_FIXSTAR, SE_STARFILE_OLD, swed.ephepath, NULL)) == NULL) {
+        swed.is_old_starfile = FALSE;
+        return ERR;
+      }
+    }
+  }
+
+  rewind(swed.fixfp);
+  while (fgets(s, AS_MAXCH, swed.fixfp) != NULL) {
+    if (*s == '#') {
+      continue;
+    }
+
+    if (swex_nstars == n) {
+      n = n == 0 ? 1024 : 2 * n;
+      star = realloc(swex_stars, n * sizeof(struct swex_star));
+      if (star == NULL) {
+        swex_free_stars();
+        return ERR;
+      }
+
+      swex_stars = star;
+    }
+
+    star = &swex_stars[swex_nstars];
+    star->line = ++swex_nstars;
+    star->data = strdup(s);
+    if (star->data == NULL) {
+      swex_nstars--;
+      swex_free_stars();
+      return ERR;
+    }
+
+    /* a line without comma is damaged and matches no star */
+    star->nomclat = strchr(star->data, ',');
+    if (star->nomclat == NULL) {
+      star->nomclat = star->data + strlen(star->data);
+      *star->name = '\0';
+      continue;
+    }
+
+    for (sp = star->data, dp = star->name; sp < star->nomclat && dp < star->name + SE_MAX_STNAME; sp++) {
+      if (*sp != ' ') {
+        *dp++ = tolower((int) *sp);
+      }
+    }
+
+    *dp = '\0';
+  }
+
+  swex_stars_by_name = malloc((swex_nstars + 1) * sizeof(struct swex_star *));
+  swex_stars_by_nomclat = malloc((swex_nstars + 1) * sizeof(struct swex_star *));
+  if (swex_stars_by_name == NULL || swex_stars_by_nomclat == NULL) {
+    swex_free_stars();
+    return ERR;
+  }
+
+  for (i = 0; i < swex_nstars; i++) {
+    swex_stars_by_name[i] = &swex_stars[i];
+    swex_stars_by_nomclat[i] = &swex_stars[i];
+  }
+
+  qsort(swex_stars_by_name, swex_nstars, sizeof(struct swex_star *), swex_cmp_star_name);
+  qsort(swex_stars_by_nomclat, swex_nstars, sizeof(struct swex_star *), swex_cmp_star_nomclat);
+  swex_stars_fp = swed.fixfp;
+  strcpy(swex_stars_path, swed.ephepath);
+  return OK;
+}
+
+/* swex_search_star returns the first star in the star file of which the name
+ * or nomenclature starts with key. */
+static struct swex_star *swex_search_star(AS_BOOL nomclat, const char *key) {
+  struct swex_star **index = nomclat ? swex_stars_by_nomclat : swex_stars_by_name;
+  struct swex_star *found = NULL;
+  size_t len = strlen(key);
+  const char *field;
+  int lo = 0, hi = swex_nstars, mid;
+
+  while (lo < hi) {
+    mid = (lo + hi) / 2;
+    field = nomclat ? index[mid]->nomclat : index[mid]->name;
+    if (strncmp(field, key, len) < 0) {
+      lo = mid + 1;
+    } else {
+      hi = mid;
+    }
+  }
+
+  for (; lo < swex_nstars; lo++) {
+    field = nomclat ? index[lo]->nomclat : index[lo]->name;
+    if (strncmp(field, key, len) != 0) {
+      break;
+    }
+
+    if (found == NULL || index[lo]->line < found->line) {
+      found = index[lo];
+    }
+  }
+
+  return found;
+}
+
+static int32 swex_fixstar2_any(AS_BOOL ut, char *star, double tjd, int32 iflag, double *xx, char *serr) {
+  char sstar[SE_MAX_STNAME + 1], *sp, *buf;
+  struct swex_star *found = NULL;
+  int star_nr = 0, i;
+  size_t len;
+  FILE *fp, *fixfp;
+  int32 retc;
+
+  /* the star name is normalized like swe_fixstar does */
+  strncpy(sstar, star, SE_MAX_STNAME);
+  sstar[SE_MAX_STNAME] = '\0';
+  if (*sstar != ',') {
+    if (isdigit((int) *sstar)) {
+      star_nr = atoi(sstar);
+    } else {
+      for (sp = sstar; *sp != '\0'; sp++) {
+        *sp = tolower((int) *sp);
+      }
+
+      if ((sp = strchr(sstar, ',')) != NULL) {
+        *sp = '\0';
+      }
+    }
+  }
+
+  while ((sp = strchr(sstar, ' ')) != NULL) {
+    memmove(sp, sp + 1, strlen(sp));
+  }
+
+  /* swe_fixstar reports an empty name and provides some stars required by
+   * the ayanamshas without a star file */
+  if (*sstar == '\0' || swex_load_stars() == ERR) {
+    return ut ? swe_fixstar_ut(star, tjd, iflag, xx, serr) : swe_fixstar(star, tjd, iflag, xx, serr);
+  }
+
+  if (star_nr > 0) {
+    if (star_nr <= swex_nstars) {
+      found = &swex_stars[star_nr - 1];
+    }
+  } else {
+    found = swex_search_star(*sstar == ',', sstar);
+  }
+
+  if (found == NULL) {
+    if (serr != NULL) {
+      sprintf(serr, "star  not found");
+      if (strlen(serr) + strlen(star) < AS_MAXCH) {
+        sprintf(serr, "star %s not found", star);
+      }
+    }
+
+    for (i = 0; i < 6; i++) {
+      xx[i] = 0;
+    }
+
+    return ERR;
+  }
+
+  /* lines before the star found are skipped unparsed if it is searched by
+   * number */
+  len = 2 * (found->line - 1) + strlen(found->data);
+  buf = malloc(len + 1);
+  if (buf == NULL) {
+    return ut ? swe_fixstar_ut(star, tjd, iflag, xx, serr) : swe_fixstar(star, tjd, iflag, xx, serr);
+  }
+
+  for (sp = buf, i = 1; star_nr > 0 && i < found->line; i++) {
+    *sp++ = '-';
+    *sp++ = '\n';
+  }
+
+  strcpy(sp, found->data);
+  len = strlen(buf);
+  if ((fp = fmemopen(buf, len, "r")) == NULL) {
+    free(buf);
+    return ut ? swe_fixstar_ut(star, tjd, iflag, xx, serr) : swe_fixstar(star, tjd, iflag, xx, serr);
+  }
+
+  /* This relies on private internals of the vendored Swiss Ephemeris: swed
+   * and its star file handle are not part of the public API, and swe_fixstar
+   * must read the star file only through swed.fixfp. Check this when the
+   * library is updated. */
+  fixfp = swed.fixfp;
+  swed.fixfp = fp;
+  retc = ut ? swe_fixstar_ut(star, tjd, iflag, xx, serr) : swe_fixstar(star, tjd, iflag, xx, serr);
+  swed.fixfp = fixfp;
+  fclose(fp);
+  free(buf);
+  return retc;
+}
+
+int32_t swex_fixstar2(char *star, double tjd_et, int32_t iflag, double *xx, char *serr) {
+  return swex_fixstar2_any(FALSE, star, tjd_et, iflag, xx, serr);
+}
+
+int32_t swex_fixstar2_ut(char *star, double tjd_ut, int32_t iflag, double *xx, char *serr) {
+  return swex_fixstar2_any(TRUE, star, tjd_ut, iflag, xx, serr);
+}
+
+/* Planetocentric positions, a local reimplementation modelled on the
+ * swe_calc_pctr API of Swiss Ephemeris 2.07. The barycentric positions of the
+ * body and the center are computed with swe_calc and the apparent position of
+ * the body as seen from the center is derived like the geocentric position in
+ * sweph.c, except that the gravitational deflection of light is not applied. */
+
+#define SWEX_EPHMASK (SEFLG_JPLEPH | SEFLG_SWIEPH | SEFLG_MOSEPH)
+
+static AS_BOOL swex_pctr_possible(int32 ipl) {
+  return !((ipl >= SE_MEAN_NODE && ipl <= SE_OSCU_APOG)
+    || ipl == SE_INTP_APOG || ipl == SE_INTP_PERG || ipl == SE_ECL_NUT);
+}
+
+int32_t swex_calc_pctr(double tjd, int32_t ipl, int32_t iplctr, int32_t iflag, double *xxret, char *serr) {
+  double xx[6], x0[6], xxctr[6], xxctr2[6], xxsp[6], xxsv[6], x2000[6], xreturn[24];
+  double dx[3], dt, t, daya, *xs;
+  char name[AS_MAXCH], serr2[AS_MAXCH];
+  struct epsilon *oe;
+  int32 iflag2, retc, epheflag;
+  int i, j, niter;
+
+  for (i = 0; i < 6; i++) {
+    xxret[i] = 0;
+  }
+
+  if (ipl == iplctr) {
+    if (serr != NULL) {
+      sprintf(serr, "ipl and iplctr (= %d) must not be identical", ipl);
+    }
+
+    return ERR;
+  }
+
+  if (!swex_pctr_possible(ipl) || !swex_pctr_possible(iplctr)) {
+    if (serr != NULL) {
+      swe_get_planet_name(swex_pctr_possible(ipl) ? iplctr : ipl, name);
+      sprintf(serr, "planetocentric position not possible for object %d = %s",
+        swex_pctr_possible(ipl) ? iplctr : ipl, name);
+    }
+
+    return ERR;
+  }
+
+  /* the center replaces the observer, the other flags like plaus_iflag in
+   * sweph.c */
+  iflag &= ~(SEFLG_HELCTR | SEFLG_BARYCTR | SEFLG_TOPOCTR | SEFLG_SPEED3
+    | SEFLG_JPLHOR | SEFLG_JPLHOR_APPROX);
+  iflag |= SEFLG_NOGDEFL;
+  if (iflag & (SEFLG_J2000 | SEFLG_SIDEREAL)) {
+    iflag |= SEFLG_NONUT;
+  }
+
+  if (iflag & SEFLG_TRUEPOS) {
+    iflag |= SEFLG_NOABERR;
+  }
+  if ((iflag & SEFLG_XYZ) && (iflag & SEFLG_RADIANS)) {
+    iflag &= ~SEFLG_RADIANS;
+  }
+
+  epheflag = iflag & SWEX_EPHMASK;
+  iflag2 = epheflag | SEFLG_HELCTR | SEFLG_J2000 | SEFLG_ICRS | SEFLG_TRUEPOS
+    | SEFLG_EQUATORIAL | SEFLG_XYZ | SEFLG_SPEED | SEFLG_NOABERR | SEFLG_NOGDEFL;
+
+  if ((retc = swe_calc(tjd, iplctr, iflag2, xxctr, serr)) == ERR) {
+    return ERR;
+  }
+
+  /* the ephemeris actually used, e.g. Moshier if the files are missing. The
+   * Moshier ephemeris has no barycentric positions, like sweph.c the Sun is
+   * taken as the barycenter then. */
+  epheflag = retc & SWEX_EPHMASK;
+  iflag = (iflag & ~SWEX_EPHMASK) | epheflag;
+  iflag2 = (iflag2 & ~SWEX_EPHMASK) | epheflag;
+  if (epheflag != SEFLG_MOSEPH) {
+    iflag2 = (iflag2 & ~SEFLG_HELCTR) | SEFLG_BARYCTR;
+    if (swe_calc(tjd, iplctr, iflag2, xxctr, serr) == ERR) {
+      return ERR;
+    }
+  }
+
+  if (swe_calc(tjd, ipl, iflag2, xx, serr) == ERR) {
+    return ERR;
+  }
+
+  t = tjd;
+
+  /* light-time */
+  if (!(iflag & SEFLG_TRUEPOS)) {
+    niter = epheflag == SEFLG_MOSEPH ? 0 : 1;
+    for (i = 0; i < 6; i++) {
+      x0[i] = xx[i];
+    }
+
+    /* part of the daily motion resulting from the change of light-time, see
+     * app_pos_etc_plan in sweph.c */
+    if (iflag & SEFLG_SPEED) {
+      for (i = 0; i <= 2; i++) {
+        xxsv[i] = xxsp[i] = xx[i] - xx[i + 3];
+      }
+
+      for (j = 0; j <= niter; j++) {
+        for (i = 0; i <= 2; i++) {
+          dx[i] = xxsp[i] - (xxctr[i] - xxctr[i + 3]);
+        }
+
+        dt = sqrt(square_sum(dx)) * AUNIT / CLIGHT / 86400.0;
+        for (i = 0; i <= 2; i++) {
+          xxsp[i] = xxsv[i] - dt * x0[i + 3];
+        }
+      }
+
+      for (i = 0; i <= 2; i++) {
+        xxsp[i] = xxsv[i] - xxsp[i];
+      }
+    }
+
+    for (j = 0; j <= niter; j++) {
+      for (i = 0; i <= 2; i++) {
+        dx[i] = xx[i] - xxctr[i];
+      }
+
+      dt = sqrt(square_sum(dx)) * AUNIT / CLIGHT / 86400.0;
+      t = tjd - dt;
+      for (i = 0; i <= 2; i++) {
+        xx[i] = x0[i] - dt * x0[i + 3];
+      }
+    }
+
+    if (iflag & SEFLG_SPEED) {
+      for (i = 0; i <= 2; i++) {
+        xxsp[i] = x0[i] - xx[i] - xxsp[i];
+      }
+    }
+
+    /* accurate position at the time the light left the body */
+    if (swe_calc(t, ipl, iflag2, xx, serr2) == ERR || swe_calc(t, iplctr, iflag2, xxctr2, serr2) == ERR) {
+      if (serr != NULL) {
+        strcpy(serr, serr2);
+      }
+
+      return ERR;
+    }
+  }
+
+  for (i = 0; i < 6; i++) {
+    xx[i] -= xxctr[i];
+  }
+
+  if (!(iflag & SEFLG_TRUEPOS) && (iflag & SEFLG_SPEED)) {
+    for (i = 3; i < 6; i++) {
+      xx[i] -= xxsp[i - 3];
+    }
+  }
+
+  if (!(iflag & SEFLG_SPEED)) {
+    for (i = 3; i < 6; i++) {
+      xx[i] = 0;
+    }
+  }
+
+  /* aberration of light by the motion of the center */
+  if (!(iflag & SEFLG_TRUEPOS) && !(iflag & SEFLG_NOABERR)) {
+    swi_aberr_light(xx, xxctr, iflag);
+    if (iflag & SEFLG_SPEED) {
+      for (i = 3; i < 6; i++) {
+        xx[i] += xxctr[i] - xxctr2[i];
+      }
+    }
+  }
+
+  /* ICRS to J2000 */
+  if (!(iflag & SEFLG_ICRS) && swi_get_denum(SEI_SUN, epheflag) >= 403) {
+    swi_bias(xx, t, iflag, FALSE);
+  }
+
+  for (i = 0; i < 6; i++) {
+    x2000[i] = xx[i];
+  }
+
+  /* swe_calc may have left the obliquity and nutation of another date */
+  swi_check_ecliptic(tjd, iflag);
+  swi_check_nutation(tjd, iflag);
+
+  /* precession, see app_pos_rest in sweph.c for the remainder */
+  if (!(iflag & SEFLG_J2000)) {
+    swi_precess(xx, tjd, iflag, J2000_TO_J);
+    if (iflag & SEFLG_SPEED) {
+      swi_precess_speed(xx, tjd, iflag, J2000_TO_J);
+    }
+
+    oe = &swed.oec;
+  } else {
+    oe = &swed.oec2000;
+  }
+
+  if (!(iflag & SEFLG_NONUT)) {
+    swi_nutate(xx, iflag, FALSE);
+  }
+
+  for (i = 0; i < 6; i++) {
+    xreturn[18 + i] = xx[i];
+  }
+
+  swi_coortrf2(xx, xx, oe->seps, oe->ceps);
+  if (iflag & SEFLG_SPEED) {
+    swi_coortrf2(xx + 3, xx + 3, oe->seps, oe->ceps);
+  }
+
+  if (!(iflag & SEFLG_NONUT)) {
+    swi_coortrf2(xx, xx, swed.nut.snut, swed.nut.cnut);
+    if (iflag & SEFLG_SPEED) {
+      swi_coortrf2(xx + 3, xx + 3, swed.nut.snut, swed.nut.cnut);
+    }
+  }
+
+  for (i = 0; i < 6; i++) {
+    xreturn[6 + i] = xx[i];
+  }
+
+  if (iflag & SEFLG_SIDEREAL) {
+    if (swed.sidd.sid_mode & SE_SIDBIT_ECL_T0) {
+      if (swi_trop_ra2sid_lon(x2000, xreturn + 6, xreturn + 18, iflag) != OK) {
+        return ERR;
+      }
+    } else if (swed.sidd.sid_mode & SE_SIDBIT_SSY_PLANE) {
+      if (swi_trop_ra2sid_lon_sosy(x2000, xreturn + 6, iflag) != OK) {
+        return ERR;
+      }
+    } else {
+      swi_cartpol_sp(xreturn + 6, xreturn);
+      if (swe_get_ayanamsa_ex(tjd, iflag, &daya, serr) == ERR) {
+        return ERR;
+      }
+
+      xreturn[0] -= daya * DEGTORAD;
+      swi_polcart_sp(xreturn, xreturn + 6);
+    }
+  }
+
+  swi_cartpol_sp(xreturn + 18, xreturn + 12);
+  swi_cartpol_sp(xreturn + 6, xreturn);
+  for (i = 0; i < 2; i++) {
+    xreturn[i] *= RADTODEG;
+    xreturn[i + 3] *= RADTODEG;
+    xreturn[i + 12] *= RADTODEG;
+    xreturn[i + 15] *= RADTODEG;
+  }
+
+  /* select the coordinates like swe_calc */
+  xs = iflag & SEFLG_EQUATORIAL ? xreturn + 12 : xreturn;
+  if (iflag & SEFLG_XYZ) {
+    xs += 6;
+  }
+
+  for (i = 0; i < 3; i++) {
+    xxret[i] = xs[i];
+    xxret[i + 3] = iflag & SEFLG_SPEED ? xs[i + 3] : 0;
+  }
+
+  if (iflag & SEFLG_RADIANS) {
+    for (i = 0; i < 2; i++) {
+      xxret[i] *= DEGTORAD;
+      xxret[i + 3] *= DEGTORAD;
+    }
+  }
+
+  return iflag;
+}
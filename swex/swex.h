@@ -1,4 +1,5 @@
 #include <stdbool.h>
+#include <stdint.h>
 #include <stdlib.h>
 
 bool swex_supports_tls();
@@ -6,3 +7,18 @@ void swex_set_jpl_file(const char *fname);
 void swex_set_jpl_file_len(const char *fname, size_t len);
 void swex_set_topo(double geolon, double geolat, double geoalt);
 void swex_set_sid_mode(int32_t sidm, double t0, double ayan_t0);
+void swex_get_astro_models(char *samod);
+double swex_sidtime_model(double tjd_ut, int32_t model);
+bool swex_find_ephe_file(double tjd, int ifno, char *fname);
+const char *swex_get_current_file_data(int ifno, double *tfstart, double *tfend, int *denum);
+int32_t swex_solcross(double x2cross, double jd_et, int32_t iflag, int32_t dir, double *jd_cross, char *serr);
+int32_t swex_solcross_ut(double x2cross, double jd_ut, int32_t iflag, int32_t dir, double *jd_cross, char *serr);
+int32_t swex_mooncross(double x2cross, double jd_et, int32_t iflag, double *jd_cross, char *serr);
+int32_t swex_mooncross_ut(double x2cross, double jd_ut, int32_t iflag, double *jd_cross, char *serr);
+int32_t swex_mooncross_node(double jd_et, int32_t iflag, double *jd_cross, double *xlon, double *xlat, char *serr);
+int32_t swex_mooncross_node_ut(double jd_ut, int32_t iflag, double *jd_cross, double *xlon, double *xlat, char *serr);
+int32_t swex_helio_cross(int32_t ipl, double x2cross, double jd_et, int32_t iflag, int32_t dir, double *jd_cross, char *serr);
+int32_t swex_helio_cross_ut(int32_t ipl, double x2cross, double jd_ut, int32_t iflag, int32_t dir, double *jd_cross, char *serr);
+int32_t swex_fixstar2(char *star, double tjd_et, int32_t iflag, double *xx, char *serr);
+int32_t swex_fixstar2_ut(char *star, double tjd_ut, int32_t iflag, double *xx, char *serr);
+int32_t swex_calc_pctr(double tjd, int32_t ipl, int32_t iplctr, int32_t iflag, double *xxret, char *serr);
@@ -4564,8 +4564,8 @@ file_damage:
     }
   }
 return_error:
+  /* local patch: free(fdp->fptr) removed, see LOCAL_PATCHES */
   fclose(fdp->fptr);
-  free(fdp->fptr);
   fdp->fptr = NULL;
   free_planets();
   return(ERR);
@@ -0,0 +1,100 @@
+package swego
+
+import (
+	"math"
+	"time"
+)
+
+// JulDayFromTime returns the Julian Date in Universal Time (UT1) for t. The
+// conversion is done by UTCToJD in the Gregorian calendar and accounts for
+// leap seconds. Sub-second precision of t is preserved within the precision
+// of a float64 Julian Date, which is about 40 µs for current dates.
+func JulDayFromTime(swe Interface, t time.Time) (float64, error) {
+	t = t.UTC()
+	s := float64(t.Second()) + float64(t.Nanosecond())/1e9
+	_, ut, err := swe.UTCToJD(t.Year(), int(t.Month()), t.Day(), t.Hour(), t.Minute(), s,
+		&DateConvertFlags{Calendar: Gregorian})
+	return ut, err
+}
+
+// TimeFromJulDay returns the time for the Julian Date jd in Universal Time
+// (UT1). The conversion is done by JdUT1ToUTC in the Gregorian calendar and
+// accounts for leap seconds. The returned time is always in UTC. A time within
+// a leap second is returned as the first second of the following minute,
+// because time.Time can not represent leap seconds.
+func TimeFromJulDay(swe Interface, jd float64) (time.Time, error) {
+	y, m, d, h, i, s, err := swe.JdUT1ToUTC(jd, &DateConvertFlags{Calendar: Gregorian})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	sec := math.Floor(s)
+	nsec := math.Round((s - sec) * 1e9)
+	return time.Date(y, time.Month(m), d, h, i, int(sec), int(nsec), time.UTC), nil
+}
+
+// LocalMeanToApparent returns the local apparent time for the local mean time
+// t at geographic location loc. The clock reading of t, in its location, is
+// taken as local mean time and the returned time has the same location, so
+// only the clock reading differs by the equation of time. The longitude of loc
+// is positive east of Greenwich, like in LMTToLAT.
+func LocalMeanToApparent(swe Interface, t time.Time, loc *GeoLoc) (time.Time, error) {
+	return localTime(swe, t, loc, swe.LMTToLAT)
+}
+
+// LocalApparentToMean is the inverse of LocalMeanToApparent and returns the
+// local mean time for the local apparent time t at geographic location loc.
+// The longitude of loc is positive east of Greenwich, like in LATToLMT.
+func LocalApparentToMean(swe Interface, t time.Time, loc *GeoLoc) (time.Time, error) {
+	return localTime(swe, t, loc, swe.LATToLMT)
+}
+
+func localTime(swe Interface, t time.Time, loc *GeoLoc,
+	fn func(jd, geolon float64, fl *TimeEquFlags) (float64, error)) (time.Time, error) {
+	h := float64(t.Hour()) + float64(t.Minute())/60 +
+		(float64(t.Second())+float64(t.Nanosecond())/1e9)/3600
+	jd, err := swe.JulDay(t.Year(), int(t.Month()), t.Day(), h, Gregorian)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var geolon float64
+	if loc != nil {
+		geolon = loc.Long
+	}
+
+	jd2, err := fn(jd, geolon, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.Add(time.Duration(math.Round((jd2 - jd) * 86400e9))), nil
+}
+
+// EquationOfTimeMinutes calls TimeEqu and returns the equation of time for
+// the Julian Date jd (in Universal Time) in minutes of time. It is local
+// apparent time minus local mean time, so it is positive when the apparent
+// Sun is ahead of the mean Sun, as in early November with about +16.4
+// minutes, and negative in February with about -14.2 minutes.
+func EquationOfTimeMinutes(swe Interface, jd float64) (float64, error) {
+	e, err := swe.TimeEqu(jd, nil)
+	return e * 1440, err
+}
+
+// LocalSiderealTime returns the local apparent sidereal time in hours, in the
+// range [0, 24), for the Julian Date ut in Universal Time at geographic
+// longitude long in degrees. The longitude is positive east of Greenwich, so
+// at long 0 the result equals SidTime.
+func LocalSiderealTime(swe Interface, ut, long float64) (float64, error) {
+	gst, err := swe.SidTime(ut, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	lst := math.Mod(gst+long/15, 24)
+	if lst < 0 {
+		lst += 24
+	}
+
+	return lst, nil
+}
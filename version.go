@@ -0,0 +1,101 @@
+package swego
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SemVer represents a parsed Swiss Ephemeris version, such as "2.06" or
+// "2.10.03". Pre contains a suffix following the version numbers, such as
+// "b" in "2.10.03b" or "beta" in "2.07-beta".
+type SemVer struct {
+	Major, Minor, Patch int
+	Pre                 string
+}
+
+// ParseVersion parses version string s as returned by Version. The patch
+// number is optional and defaults to 0.
+func ParseVersion(s string) (SemVer, error) {
+	var v SemVer
+
+	num := s
+	if i := strings.IndexFunc(s, func(r rune) bool {
+		return r != '.' && (r < '0' || r > '9')
+	}); i >= 0 {
+		num, v.Pre = s[:i], strings.TrimLeft(s[i:], "-")
+	}
+
+	parts := strings.Split(num, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return SemVer{}, Error{Code: -1, Message: "invalid version: " + strconv.Quote(s)}
+	}
+
+	nums := [3]*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return SemVer{}, Error{Code: -1, Message: "invalid version: " + strconv.Quote(s)}
+		}
+
+		*nums[i] = n
+	}
+
+	return v, nil
+}
+
+// VersionOf returns the parsed version of the library behind swe.
+func VersionOf(swe Interface) (SemVer, error) {
+	s, err := swe.Version()
+	if err != nil {
+		return SemVer{}, err
+	}
+
+	return ParseVersion(s)
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower than, equal to
+// or higher than w. A version with a suffix is lower than the same version
+// without one, suffixes are compared lexically.
+func (v SemVer) Compare(w SemVer) int {
+	for _, d := range [...]int{v.Major - w.Major, v.Minor - w.Minor, v.Patch - w.Patch} {
+		if d < 0 {
+			return -1
+		}
+
+		if d > 0 {
+			return 1
+		}
+	}
+
+	switch {
+	case v.Pre == w.Pre:
+		return 0
+	case v.Pre == "":
+		return 1
+	case w.Pre == "":
+		return -1
+	}
+
+	return strings.Compare(v.Pre, w.Pre)
+}
+
+// String returns the version in the format of the library, for example
+// "2.06", "2.10.03" or "2.10.03b". Like the library it omits a patch number of
+// 0 and appends the suffix without separator, so a "-" before the suffix, as
+// in "2.07-beta", is not restored.
+func (v SemVer) String() string {
+	s := strconv.Itoa(v.Major) + "." + pad2(v.Minor)
+	if v.Patch != 0 {
+		s += "." + pad2(v.Patch)
+	}
+
+	return s + v.Pre
+}
+
+func pad2(n int) string {
+	if n >= 0 && n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+
+	return strconv.Itoa(n)
+}